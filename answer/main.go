@@ -2,7 +2,11 @@ package answer
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+
+	"golang.org/x/term"
 )
 
 type Answer int
@@ -28,3 +32,22 @@ func Ask() Answer {
 
 	return NO
 }
+
+// Interactive returns true if we can ask the user a question
+func Interactive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Choose asks the user to choose between count options, returns the (zero-based) index of the option or -1
+func Choose(count int) int {
+	fmt.Printf("Please enter a number between 1 and %d: ", count)
+
+	var answer string
+	if _, err := fmt.Scanln(&answer); err == nil {
+		if i, err := strconv.Atoi(strings.TrimSpace(answer)); err == nil && i >= 1 && i <= count {
+			return i - 1
+		}
+	}
+
+	return -1
+}
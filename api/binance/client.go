@@ -19,7 +19,13 @@ var (
 )
 
 func New(apiKey, apiSecret string) (*Client, error) {
+	baseURL, err := flag.BaseURL(binance.BaseAPIMainURL, binance.BaseAPITestnetURL)
+	if err != nil {
+		return nil, err
+	}
+
 	client := binance.NewClient(apiKey, apiSecret)
+	client.BaseURL = baseURL
 	client.HTTPClient = &http.Client{
 		Timeout: 30 * time.Second,
 	}
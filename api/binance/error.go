@@ -2,6 +2,8 @@ package binance
 
 import (
 	"context"
+	"strings"
+
 	"github.com/adshao/go-binance/v2"
 	"github.com/adshao/go-binance/v2/common"
 )
@@ -17,6 +19,18 @@ func isBinanceError(err error) (*common.APIError, bool) {
 	return nil, false
 }
 
+// Returns true if the order does not exist (anymore), for example because it has been filled or cancelled
+func IsUnknownOrder(err error) bool {
+	apiError, ok := isBinanceError(err)
+	return ok && apiError.Code == -2011
+}
+
+// Returns true if a LIMIT_MAKER order was rejected because it would immediately match and trade as a taker
+func IsPostOnlyRejected(err error) bool {
+	apiError, ok := isBinanceError(err)
+	return ok && apiError.Code == -2010 && strings.Contains(apiError.Message, "immediately match")
+}
+
 // You can ignore this error and continue with the next for-loop iteration
 type errorContinue struct{}
 
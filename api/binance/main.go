@@ -67,7 +67,7 @@ func (self *Client) CancelOrder(symbol string, orderID int64) error {
 	return nil
 }
 
-func (self *Client) CreateOrder(symbol string, side consts.OrderSide, size, price float64) (*binance.CreateOrderResponse, error) {
+func (self *Client) newCreateOrderService(symbol string, side consts.OrderSide, size, price float64, postOnly bool) *binance.CreateOrderService {
 	clientOrderId := func() string {
 		const (
 			MAX_LEN = 36
@@ -81,21 +81,28 @@ func (self *Client) CreateOrder(symbol string, side consts.OrderSide, size, pric
 		return out
 	}()
 
+	service := self.inner.NewCreateOrderService().
+		Symbol(symbol).
+		Side(binance.SideType(side.ToUpperCase())).
+		Quantity(strconv.FormatFloat(size, 'f', -1, 64)).
+		Price(strconv.FormatFloat(price, 'f', -1, 64)).
+		NewClientOrderID(clientOrderId)
+
+	// LIMIT_MAKER orders are rejected if they would immediately match and trade as a taker
+	if postOnly {
+		return service.Type(binance.OrderTypeLimitMaker)
+	}
+	return service.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC)
+}
+
+func (self *Client) CreateOrder(symbol string, side consts.OrderSide, size, price float64, postOnly bool) (*binance.CreateOrderResponse, error) {
 	var order *binance.CreateOrderResponse
 	for {
 		var err error
 		order, err = func() (*binance.CreateOrderResponse, error) {
 			beforeRequest(*self.inner, createOrder)
 			defer afterRequest()
-			return self.inner.NewCreateOrderService().
-				Symbol(symbol).
-				Side(binance.SideType(side.ToUpperCase())).
-				Type(binance.OrderTypeLimit).
-				TimeInForce(binance.TimeInForceTypeGTC).
-				Quantity(strconv.FormatFloat(size, 'f', -1, 64)).
-				Price(strconv.FormatFloat(price, 'f', -1, 64)).
-				NewClientOrderID(clientOrderId).
-				Do(context.Background())
+			return self.newCreateOrderService(symbol, side, size, price, postOnly).Do(context.Background())
 		}()
 		if err == nil {
 			break
@@ -107,3 +114,21 @@ func (self *Client) CreateOrder(symbol string, side consts.OrderSide, size, pric
 
 	return order, nil
 }
+
+// TestOrder validates a new order, but does not send it into the matching engine
+func (self *Client) TestOrder(symbol string, side consts.OrderSide, size, price float64, postOnly bool) error {
+	for {
+		err := func() error {
+			beforeRequest(*self.inner, testOrder)
+			defer afterRequest()
+			return self.newCreateOrderService(symbol, side, size, price, postOnly).Test(context.Background())
+		}()
+		if err == nil {
+			break
+		}
+		if _, ok := handleRecvWindowError(self.inner, err).(*errorContinue); !ok {
+			return err
+		}
+	}
+	return nil
+}
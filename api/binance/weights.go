@@ -8,6 +8,7 @@ const (
 	exchangeInfo
 	openOrders
 	serverTime
+	testOrder
 	tickerPrice
 )
 
@@ -17,5 +18,6 @@ var weight = map[request]int{
 	exchangeInfo: 10,
 	openOrders:   3,
 	serverTime:   1,
+	testOrder:    1,
 	tickerPrice:  1,
 }
@@ -63,7 +63,7 @@ func (self *Client) get(path string) ([]byte, error) {
 	}
 
 	// set the endpoint for this request
-	endpoint.Path += path
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + apiPath + path
 
 	resp, err := self.httpClient.Get(endpoint.String())
 	if err != nil {
@@ -100,7 +100,7 @@ func (self *Client) post(path string, values url.Values) ([]byte, error) {
 	}
 
 	// set the endpoint for this request
-	endpoint.Path += path
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + apiPath + path
 
 	// encode the url.Values in the body
 	payload := values.Encode()
@@ -134,7 +134,7 @@ func (self *Client) post(path string, values url.Values) ([]byte, error) {
 	x_auth_message := x_auth +
 		req.Method +
 		req.Host +
-		"/api/v2" + path +
+		apiPath + path +
 		"" +
 		func() string { // content_type
 			if payload == "" {
@@ -270,10 +270,33 @@ func (self *Client) Ticker(pair string) (*Ticker, error) {
 	return &out, nil
 }
 
-func (self *Client) BuyLimitOrder(pair string, amount, price float64) (*Order, error) {
+// returns the values for a limit order. if expiry is zero, the order is good-til-cancelled.
+// a maker-or-cancel order is cancelled (rather than filled) if it would have traded as a taker.
+func limitOrder(amount, price float64, expiry time.Time, makerOrCancel bool) url.Values {
 	values := url.Values{}
 	values.Add("amount", strconv.FormatFloat(amount, 'f', -1, 64))
 	values.Add("price", strconv.FormatFloat(price, 'f', -1, 64))
+	if !expiry.IsZero() {
+		values.Add("gtd_order", "True")
+		values.Add("expire_time", strconv.FormatInt(expiry.UnixMilli(), 10))
+	}
+	if makerOrCancel {
+		values.Add("moc_order", "True")
+	}
+	return values
+}
+
+// Returns true if a maker-or-cancel order was rejected because it would have traded as a taker
+func IsPostOnlyRejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "maker or cancel") || strings.Contains(msg, "maker-or-cancel") || strings.Contains(msg, "moc order")
+}
+
+func (self *Client) BuyLimitOrder(pair string, amount, price float64, expiry time.Time, makerOrCancel bool) (*Order, error) {
+	values := limitOrder(amount, price, expiry, makerOrCancel)
 
 	body, err := self.post(fmt.Sprintf("/buy/%s/", pair), values)
 	if err != nil {
@@ -288,10 +311,8 @@ func (self *Client) BuyLimitOrder(pair string, amount, price float64) (*Order, e
 	return &out, nil
 }
 
-func (client *Client) SellLimitOrder(pair string, amount, price float64) (*Order, error) {
-	values := url.Values{}
-	values.Add("amount", strconv.FormatFloat(amount, 'f', -1, 64))
-	values.Add("price", strconv.FormatFloat(price, 'f', -1, 64))
+func (client *Client) SellLimitOrder(pair string, amount, price float64, expiry time.Time, makerOrCancel bool) (*Order, error) {
+	values := limitOrder(amount, price, expiry, makerOrCancel)
 
 	body, err := client.post(fmt.Sprintf("/sell/%s/", pair), values)
 	if err != nil {
@@ -306,18 +327,28 @@ func (client *Client) SellLimitOrder(pair string, amount, price float64) (*Order
 	return &out, nil
 }
 
-func ReadOnly() *Client {
+func ReadOnly() (*Client, error) {
+	baseURL, err := flag.BaseURL(endpoint, "")
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
-		endpoint,
+		baseURL,
 		"",
 		"",
 		http.Client{
 			Timeout: 30 * time.Second,
 		},
-	}
+	}, nil
 }
 
 func ReadWrite() (*Client, error) {
+	baseURL, err := flag.BaseURL(endpoint, "")
+	if err != nil {
+		return nil, err
+	}
+
 	apiKey, err := flag.ApiKey()
 	if err != nil {
 		return nil, err
@@ -329,7 +360,7 @@ func ReadWrite() (*Client, error) {
 	}
 
 	return &Client{
-		endpoint,
+		baseURL,
 		apiKey,
 		apiSecret,
 		http.Client{
@@ -5,7 +5,8 @@ import (
 )
 
 const (
-	endpoint = "https://www.bitstamp.net/api/v2"
+	endpoint = "https://www.bitstamp.net"
+	apiPath  = "/api/v2" // every request goes to endpoint + apiPath + path
 )
 
 var (
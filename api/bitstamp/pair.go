@@ -1,5 +1,12 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
 package bitstamp
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 type Pair struct {
 	BaseDecimals    int    `json:"base_decimals"`    // size precision
 	MinimumOrder    string `json:"minimum_order"`    // minimum order size
@@ -8,3 +15,17 @@ type Pair struct {
 	UrlSymbol       string `json:"url_symbol"`       // name
 	Description     string `json:"description"`
 }
+
+// Validate checks an order against the trading rules of this pair. Bitstamp does not have a validation endpoint.
+func (self *Pair) Validate(amount, price float64) error {
+	if !strings.EqualFold(self.Trading, "enabled") {
+		return fmt.Errorf("market %s is %s", self.UrlSymbol, self.Trading)
+	}
+	// minimum order is formatted like "10.0 USD" (in counter currency)
+	if fields := strings.Fields(self.MinimumOrder); len(fields) > 0 {
+		if minimum, err := strconv.ParseFloat(fields[0], 64); err == nil && (amount*price) < minimum {
+			return fmt.Errorf("minimum order size is %s", self.MinimumOrder)
+		}
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 )
 
 type Client struct {
+	baseURL    string
 	apiKey     string
 	apiSecret  string
 	httpClient http.Client
@@ -83,7 +84,7 @@ func (self *Client) auth(request *http.Request, method, path, body string) error
 				NotBefore: jwt.NewNumericDate(time.Now()),
 				Expiry:    jwt.NewNumericDate(time.Now().Add(2 * time.Minute)),
 			},
-			URI: fmt.Sprintf("%s %s%s", method, "api.coinbase.com", format(path)),
+			URI: fmt.Sprintf("%s %s%s", method, request.URL.Host, format(path)),
 		}
 
 		bearer, err := jwt.Signed(sig).Claims(claims).CompactSerialize()
@@ -140,7 +141,7 @@ func (self *Client) get(path string, values *url.Values) ([]byte, error) {
 	defer afterRequest()
 
 	request, err := http.NewRequest("GET", func() string {
-		result := apiBase + format(path)
+		result := self.baseURL + format(path)
 		if values != nil {
 			result += "?" + values.Encode()
 		}
@@ -161,7 +162,7 @@ func (self *Client) post(path string, body []byte) ([]byte, error) {
 	beforeRequest()
 	defer afterRequest()
 
-	request, err := http.NewRequest("POST", (apiBase + format(path)), func() io.Reader {
+	request, err := http.NewRequest("POST", (self.baseURL + format(path)), func() io.Reader {
 		if body != nil {
 			return bytes.NewReader(body)
 		}
@@ -187,6 +188,11 @@ func (self *Client) post(path string, body []byte) ([]byte, error) {
 }
 
 func New() (*Client, error) {
+	baseURL, err := flag.BaseURL(apiBase, apiSandbox)
+	if err != nil {
+		return nil, err
+	}
+
 	apiKey, err := flag.ApiKey()
 	if err != nil {
 		return nil, err
@@ -198,6 +204,7 @@ func New() (*Client, error) {
 	}
 
 	return &Client{
+		baseURL,
 		apiKey,
 		apiSecret,
 		http.Client{
@@ -2,6 +2,7 @@ package coinbase
 
 const (
 	apiBase              = "https://api.coinbase.com"
+	apiSandbox           = "https://api-sandbox.coinbase.com"
 	apiVersion           = "v3"
 	apiRequestsPerSecond = 30
 )
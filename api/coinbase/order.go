@@ -4,27 +4,57 @@ package coinbase
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/url"
+	"strings"
+	"time"
 
 	consts "github.com/svanas/ladder/constants"
 	"github.com/svanas/ladder/uuid"
 )
 
+type Limit struct {
+	Size     float64 `json:"base_size,string"`   // amount of base currency to spend on order
+	Price    float64 `json:"limit_price,string"` // ceiling price for which the order should get filled
+	EndTime  string  `json:"end_time,omitempty"` // time at which the order should be cancelled if it's not filled (good-til-date only)
+	PostOnly bool    `json:"post_only"`          // post only limit order
+}
+
+type Configuration struct {
+	LimitGtc *Limit `json:"limit_limit_gtc,omitempty"` // good-til-cancelled
+	LimitGtd *Limit `json:"limit_limit_gtd,omitempty"` // good-til-date
+}
+
+func newConfiguration(size, price float64, expiry time.Time, postOnly bool) Configuration {
+	limit := &Limit{
+		Size:     size,
+		Price:    price,
+		PostOnly: postOnly,
+	}
+	if expiry.IsZero() {
+		return Configuration{LimitGtc: limit}
+	}
+	limit.EndTime = expiry.UTC().Format(time.RFC3339)
+	return Configuration{LimitGtd: limit}
+}
+
+// returns the limit order configuration, or nil if this isn't a limit order
+func (self *Configuration) Limit() *Limit {
+	if self.LimitGtc != nil {
+		return self.LimitGtc
+	}
+	return self.LimitGtd
+}
+
 type Order struct {
-	OrderId       string `json:"order_id"`   // unique id for this order
-	ProductId     string `json:"product_id"` // product this order was created for e.g. 'BTC-USD'
-	UserId        string `json:"user_id"`    // id of the user owning this Order
-	Configuration struct {
-		Limit struct {
-			Size     float64 `json:"base_size,string"`   // amount of base currency to spend on order
-			Price    float64 `json:"limit_price,string"` // ceiling price for which the order should get filled
-			PostOnly bool    `json:"post_only"`          // post only limit order
-		} `json:"limit_limit_gtc"`
-	} `json:"order_configuration"`
-	Side          string `json:"side"`            // possible values are: [UNKNOWN_ORDER_SIDE, BUY, SELL]
-	ClientOrderId string `json:"client_order_id"` // client specified ID of order
-	Status        string `json:"status"`          // possible values are: [OPEN, FILLED, CANCELLED, EXPIRED, FAILED, UNKNOWN_ORDER_STATUS]
-	TimeInForce   string `json:"time_in_force"`   // possible values are: [UNKNOWN_TIME_IN_FORCE, GOOD_UNTIL_DATE_TIME, GOOD_UNTIL_CANCELLED, IMMEDIATE_OR_CANCEL, FILL_OR_KILL]
+	OrderId       string        `json:"order_id"`            // unique id for this order
+	ProductId     string        `json:"product_id"`          // product this order was created for e.g. 'BTC-USD'
+	UserId        string        `json:"user_id"`             // id of the user owning this Order
+	Configuration Configuration `json:"order_configuration"` // limit order configuration
+	Side          string        `json:"side"`                // possible values are: [UNKNOWN_ORDER_SIDE, BUY, SELL]
+	ClientOrderId string        `json:"client_order_id"`     // client specified ID of order
+	Status        string        `json:"status"`              // possible values are: [OPEN, FILLED, CANCELLED, EXPIRED, FAILED, UNKNOWN_ORDER_STATUS]
+	TimeInForce   string        `json:"time_in_force"`       // possible values are: [UNKNOWN_TIME_IN_FORCE, GOOD_UNTIL_DATE_TIME, GOOD_UNTIL_CANCELLED, IMMEDIATE_OR_CANCEL, FILL_OR_KILL]
 }
 
 func (self *Client) GetOpenOrders(market string, side consts.OrderSide) ([]Order, error) {
@@ -46,26 +76,21 @@ func (self *Client) GetOpenOrders(market string, side consts.OrderSide) ([]Order
 	return response.Orders, nil
 }
 
-func (self *Client) CreateOrder(market string, side consts.OrderSide, size, price float64) (string, error) { // --> (orderId, error)
+// CreateOrder places a limit order. if expiry is zero, the order is good-til-cancelled, otherwise good-til-date.
+func (self *Client) CreateOrder(market string, side consts.OrderSide, size, price float64, expiry time.Time, postOnly bool) (string, error) { // --> (orderId, error)
 	type Request struct {
-		ClientOrderId string `json:"client_order_id"`
-		ProductId     string `json:"product_id"`
-		Side          string `json:"side"`
-		Configuration struct {
-			Limit struct {
-				Size  float64 `json:"base_size,string"`
-				Price float64 `json:"limit_price,string"`
-			} `json:"limit_limit_gtc"`
-		} `json:"order_configuration"`
+		ClientOrderId string        `json:"client_order_id"`
+		ProductId     string        `json:"product_id"`
+		Side          string        `json:"side"`
+		Configuration Configuration `json:"order_configuration"`
 	}
 
 	request := Request{
 		ClientOrderId: uuid.New().String(),
 		ProductId:     market,
 		Side:          side.String(),
+		Configuration: newConfiguration(size, price, expiry, postOnly),
 	}
-	request.Configuration.Limit.Size = size
-	request.Configuration.Limit.Price = price
 
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -92,6 +117,9 @@ func (self *Client) CreateOrder(market string, side consts.OrderSide, size, pric
 		return "", err
 	}
 	if !response.Success {
+		if strings.Contains(response.ErrorResponse.Error, "POST_ONLY") {
+			return "", fmt.Errorf("%s: %s", response.ErrorResponse.Error, response.ErrorResponse.Message)
+		}
 		if response.ErrorResponse.Message != "" {
 			return "", errors.New(response.ErrorResponse.Message)
 		} else {
@@ -102,6 +130,49 @@ func (self *Client) CreateOrder(market string, side consts.OrderSide, size, pric
 	return response.SuccessResponse.OrderId, nil
 }
 
+// Returns true if a post-only order was rejected because it would have traded as a taker
+func IsPostOnlyRejected(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "POST_ONLY")
+}
+
+// PreviewOrder simulates a new order, but does not place it
+func (self *Client) PreviewOrder(market string, side consts.OrderSide, size, price float64, expiry time.Time, postOnly bool) error {
+	type Request struct {
+		ProductId     string        `json:"product_id"`
+		Side          string        `json:"side"`
+		Configuration Configuration `json:"order_configuration"`
+	}
+
+	request := Request{
+		ProductId:     market,
+		Side:          side.String(),
+		Configuration: newConfiguration(size, price, expiry, postOnly),
+	}
+
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return err
+	}
+
+	data, err := self.post("orders/preview", body)
+	if err != nil {
+		return err
+	}
+
+	type Response struct {
+		Errs []string `json:"errs"`
+	}
+	var response Response
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+	if len(response.Errs) > 0 {
+		return errors.New(strings.Join(response.Errs, ", "))
+	}
+
+	return nil
+}
+
 func (self *Client) CancelOrders(orderIds []string) error {
 	type Request struct {
 		OrderIds []string `json:"order_ids"`
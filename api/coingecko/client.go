@@ -7,7 +7,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"reflect"
 	"strings"
 	"time"
 )
@@ -23,29 +22,11 @@ type Ticker struct {
 }
 
 type Coin struct {
-	Id        string `json:"id"`
-	Symbol    string `json:"symbol"`
-	Platforms struct {
-		Ethereum           string `json:"ethereum,omitempty"`
-		OptimisticEthereum string `json:"optimistic-ethereum,omitempty"`
-		BinanceSmartChain  string `json:"binance-smart-chain,omitempty"`
-		PolygonPos         string `json:"polygon-pos,omitempty"`
-		Fantom             string `json:"fantom,omitempty"`
-		Base               string `json:"base,omitempty"`
-		ArbitrumOne        string `json:"arbitrum-one,omitempty"`
-		Avalanche          string `json:"avalanche,omitempty"`
-	} `json:"platforms,omitempty"`
-	Details struct {
-		Ethereum           *Details `json:"ethereum,omitempty"`
-		OptimisticEthereum *Details `json:"optimistic-ethereum,omitempty"`
-		BinanceSmartChain  *Details `json:"binance-smart-chain,omitempty"`
-		PolygonPos         *Details `json:"polygon-pos,omitempty"`
-		Fantom             *Details `json:"fantom,omitempty"`
-		Base               *Details `json:"base,omitempty"`
-		ArbitrumOne        *Details `json:"arbitrum-one,omitempty"`
-		Avalanche          *Details `json:"avalanche,omitempty"`
-	} `json:"detail_platforms,omitempty"`
-	Tickers []Ticker `json:"tickers,omitempty"`
+	Id        string              `json:"id"`
+	Symbol    string              `json:"symbol"`
+	Platforms map[string]string   `json:"platforms,omitempty"`        // the address of this coin, per asset platform
+	Details   map[string]*Details `json:"detail_platforms,omitempty"` // the decimals of this coin, per asset platform
+	Tickers   []Ticker            `json:"tickers,omitempty"`
 }
 
 type Client struct {
@@ -53,6 +34,7 @@ type Client struct {
 	httpClient http.Client
 	coins      []Coin
 	coin       map[string]Coin
+	platforms  map[int64]string // the CoinGecko asset platform, per chain id
 }
 
 func (client *Client) get(path string, args url.Values) ([]byte, error) {
@@ -110,29 +92,66 @@ func (client *Client) getCoins() ([]Coin, error) {
 	return client.coins, nil
 }
 
-func (client *Client) GetCoin(symbol string, chainId int64) (string, string, string, error) { // --> (coinId, symbol, address, error)
-	chainName, err := chainName(chainId)
+// Match is a coin that matches a symbol (or an address) on a chain
+type Match struct {
+	Id      string
+	Symbol  string
+	Address string
+}
+
+// GetCoins returns every coin that matches the symbol (or the address) on this chain
+func (client *Client) GetCoins(symbol string, chainId int64) ([]Match, error) {
+	platform, err := client.platform(chainId)
 	if err != nil {
-		return "", "", "", err
+		return nil, err
 	}
 	coins, err := client.getCoins()
 	if err != nil {
-		return "", "", "", err
+		return nil, err
 	}
+	var out []Match
 	for _, coin := range coins {
-		if strings.EqualFold(coin.Symbol, symbol) || (len(symbol) == 42 && strings.HasPrefix(strings.ToLower(symbol), "0x")) {
-			v := reflect.ValueOf(coin.Platforms)
-			for i := 0; i < v.NumField(); i++ {
-				if strings.EqualFold(v.Type().Field(i).Name, strings.ReplaceAll(chainName, "-", "")) {
-					address := v.Field(i).String()
-					if address != "" && (strings.EqualFold(coin.Symbol, symbol) || strings.EqualFold(symbol, address)) {
-						return coin.Id, coin.Symbol, address, nil
-					}
-				}
-			}
+		address := coin.Platforms[platform]
+		if address != "" && (strings.EqualFold(coin.Symbol, symbol) || strings.EqualFold(symbol, address)) {
+			out = append(out, Match{coin.Id, coin.Symbol, address})
 		}
 	}
-	return "", "", "", fmt.Errorf("token %s does not exist on chain %d", symbol, chainId)
+	if len(out) == 0 {
+		return nil, fmt.Errorf("token %s does not exist on chain %d", symbol, chainId)
+	}
+	return out, nil
+}
+
+// GetCoin returns the first coin that matches the symbol (or the address) on this chain
+func (client *Client) GetCoin(symbol string, chainId int64) (string, string, string, error) { // --> (coinId, symbol, address, error)
+	coins, err := client.GetCoins(symbol, chainId)
+	if err != nil {
+		return "", "", "", err
+	}
+	return coins[0].Id, coins[0].Symbol, coins[0].Address, nil
+}
+
+// GetMarketCaps returns the market cap (in USD) of these coins
+func (client *Client) GetMarketCaps(coinIds []string) (map[string]float64, error) {
+	args := url.Values{}
+	args.Add("vs_currency", "usd")
+	args.Add("ids", strings.Join(coinIds, ","))
+	body, err := client.get("coins/markets", args)
+	if err != nil {
+		return nil, err
+	}
+	var markets []struct {
+		Id        string  `json:"id"`
+		MarketCap float64 `json:"market_cap"`
+	}
+	if err := json.Unmarshal(body, &markets); err != nil {
+		return nil, err
+	}
+	out := make(map[string]float64)
+	for _, market := range markets {
+		out[market.Id] = market.MarketCap
+	}
+	return out, nil
 }
 
 func (client *Client) getCoin(coinId string) (*Coin, error) {
@@ -156,21 +175,15 @@ func (client *Client) GetDecimals(coinId string, chainId int64) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	chainName, err := chainName(chainId)
+	platform, err := client.platform(chainId)
 	if err != nil {
 		return 0, err
 	}
-	v := reflect.ValueOf(coin.Details)
-	for i := 0; i < v.NumField(); i++ {
-		if strings.EqualFold(v.Type().Field(i).Name, strings.ReplaceAll(chainName, "-", "")) {
-			details, ok := v.Field(i).Interface().(*Details)
-			if !ok {
-				return 0, fmt.Errorf("error casting %s.%s's interface to details", coinId, chainName)
-			}
-			return details.Decimals, nil
-		}
+	details, ok := coin.Details[platform]
+	if !ok || details == nil {
+		return 0, fmt.Errorf("%s's decimals not found on chain %s", coinId, platform)
 	}
-	return 0, fmt.Errorf("%s's decimals not found on chain %s", coinId, chainName)
+	return details.Decimals, nil
 }
 
 func (client *Client) GetTicker(coinId string) (float64, error) {
@@ -192,5 +205,6 @@ func New() *Client {
 		},
 		nil,
 		map[string]Coin{},
+		nil,
 	}
 }
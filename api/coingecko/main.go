@@ -2,7 +2,9 @@ package coingecko
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/svanas/ladder/api/web3"
 )
@@ -19,26 +21,33 @@ func apiRequestsPerSecond() float64 {
 	return float64(30) / float64(60) // 30 req/minute
 }
 
-func chainName(chainId int64) (string, error) {
-	switch chainId {
-	case web3.Ethereum:
-		return "ethereum", nil
-	case web3.Optimism:
-		return "optimistic-ethereum", nil
-	case web3.BnbChain:
-		return "binance-smart-chain", nil
-	case web3.GnosisChain:
-		return "xdai", nil
-	case web3.Polygon:
-		return "polygon-pos", nil
-	case web3.Sonic:
-		return "sonic", nil
-	case web3.Base:
-		return "base", nil
-	case web3.Arbitrum:
-		return "arbitrum-one", nil
-	case web3.Avalanche:
-		return "avalanche", nil
+// returns the CoinGecko asset platform of this chain. this is the platform in our chain registry, otherwise the
+// platform CoinGecko associates with this chain id (so that new chains work without an update to ladder).
+func (client *Client) platform(chainId int64) (string, error) {
+	if chain, err := web3.GetChain(chainId); err == nil && chain.CoinGecko != "" {
+		return chain.CoinGecko, nil
+	}
+	if client.platforms == nil {
+		body, err := client.get("asset_platforms", url.Values{})
+		if err != nil {
+			return "", err
+		}
+		var platforms []struct {
+			Id      string `json:"id"`
+			ChainId *int64 `json:"chain_identifier"`
+		}
+		if err := json.Unmarshal(body, &platforms); err != nil {
+			return "", err
+		}
+		client.platforms = make(map[int64]string)
+		for _, platform := range platforms {
+			if platform.ChainId != nil {
+				client.platforms[*platform.ChainId] = platform.Id
+			}
+		}
+	}
+	if platform, ok := client.platforms[chainId]; ok {
+		return platform, nil
 	}
 	return "", fmt.Errorf("chain %d is not supported at this time", chainId)
 }
@@ -3,7 +3,9 @@ package kraken
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/svanas/kraken-go-api-client"
@@ -61,16 +63,41 @@ func (client *Client) OpenOrders(market string) ([]Order, error) {
 	return output, nil
 }
 
-func (client *Client) CreateOrder(market string, side consts.OrderSide, size, price float64) (string, error) { // --> (txid, error)
-	result, err := client.inner.AddOrder(market, side.ToLowerCase(), "limit", strconv.FormatFloat(size, 'f', -1, 64), map[string]string{
+// returns the AddOrder arguments for a limit order. if expiry is zero, the order is good-til-cancelled.
+func args(price float64, expiry time.Time, postOnly bool) map[string]string {
+	out := map[string]string{
 		"price": strconv.FormatFloat(price, 'f', -1, 64),
-	})
+	}
+	if !expiry.IsZero() {
+		out["expiretm"] = strconv.FormatInt(expiry.Unix(), 10)
+	}
+	if postOnly {
+		out["oflags"] = "post"
+	}
+	return out
+}
+
+// Returns true if a post-only order was rejected because it would have traded as a taker
+func IsPostOnlyRejected(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Post only order")
+}
+
+func (client *Client) CreateOrder(market string, side consts.OrderSide, size, price float64, expiry time.Time, postOnly bool) (string, error) { // --> (txid, error)
+	result, err := client.inner.AddOrder(market, side.ToLowerCase(), "limit", strconv.FormatFloat(size, 'f', -1, 64), args(price, expiry, postOnly))
 	if err != nil {
 		return "", err
 	}
 	return result.TxId[0], nil
 }
 
+// ValidateOrder validates the order inputs only, but does not submit the order
+func (client *Client) ValidateOrder(market string, side consts.OrderSide, size, price float64, expiry time.Time, postOnly bool) error {
+	args := args(price, expiry, postOnly)
+	args["validate"] = "true"
+	_, err := client.inner.AddOrder(market, side.ToLowerCase(), "limit", strconv.FormatFloat(size, 'f', -1, 64), args)
+	return err
+}
+
 func (client *Client) CancelOrder(txid string) error {
 	result, err := client.inner.CancelOrder(txid)
 	if err != nil {
@@ -82,13 +109,42 @@ func (client *Client) CancelOrder(txid string) error {
 	return nil
 }
 
+// rewriter redirects every request from the production API to another base URL
+type rewriter struct {
+	baseURL *url.URL
+}
+
+func (rw *rewriter) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.URL.Scheme = rw.baseURL.Scheme
+	request.URL.Host = rw.baseURL.Host
+	request.URL.Path = strings.TrimSuffix(rw.baseURL.Path, "/") + request.URL.Path
+	request.Host = rw.baseURL.Host
+	return http.DefaultTransport.RoundTrip(request)
+}
+
 func new(apiKey, apiSecret string) (*Client, error) {
+	baseURL, err := flag.BaseURL(krakenapi.APIURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	if baseURL != krakenapi.APIURL {
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = &rewriter{parsed}
+	}
+
 	return &Client{inner: krakenapi.NewWithClient(
 		apiKey,
 		apiSecret,
-		&http.Client{
-			Timeout: 30 * time.Second,
-		})}, nil
+		httpClient,
+	)}, nil
 }
 
 func ReadOnly() (*Client, error) {
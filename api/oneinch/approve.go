@@ -0,0 +1,185 @@
+package oneinch
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/svanas/ladder/api/web3"
+)
+
+// GetAllowance returns how much of a token the 1inch router (or Permit2) is allowed to spend on your behalf
+func (client *Client) GetAllowance(token string) (*big.Int, error) {
+	owner, err := client.publicAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	spender, err := spender(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	return web3.GetAllowance(token, owner.Hex(), spender)
+}
+
+// GetCommitted returns how much of a token your open orders (on this chain) still need to spend. these orders lean
+// on your allowance, so every new approval needs to include this amount.
+func (client *Client) GetCommitted(token string) (*big.Int, error) {
+	owner, err := client.publicAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	out := new(big.Int)
+
+	orders, err := client.GetOrders()
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		if strings.EqualFold(order.Data.MakerAsset, token) {
+			remaining, err := order.GetRemainingAmount()
+			if err != nil {
+				return nil, err
+			}
+			out.Add(out, remaining)
+		}
+	}
+
+	// we don't know how much of a cross-chain order has been filled, so we assume none of it has
+	crossChainOrders, err := LoadCrossChainOrders()
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range crossChainOrders {
+		if order.SrcChainId == client.ChainId && strings.EqualFold(order.Data.Maker, owner.Hex()) && strings.EqualFold(order.Data.MakerAsset, token) {
+			amount, err := order.Data.GetMakerAmount()
+			if err != nil {
+				return nil, err
+			}
+			out.Add(out, amount)
+		}
+	}
+
+	return out, nil
+}
+
+// EstimateApprove returns the EIP-1559 gas estimate for approving the 1inch router (or Permit2) to spend a token.
+// if reset is true, the estimate includes a transaction that resets your allowance to zero first.
+func (client *Client) EstimateApprove(token string, amount *big.Int, reset bool) (*web3.Fee, error) {
+	owner, err := client.publicAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	spender, err := spender(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := web3.ApproveData(spender, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reset {
+		return web3.Estimate(owner, common.HexToAddress(token), data)
+	}
+
+	zero, err := client.EstimateApprove(token, new(big.Int), false)
+	if err != nil {
+		return nil, err
+	}
+	// your approval reverts until your allowance has been reset, so we estimate it on behalf of a wallet without an allowance
+	fee, err := web3.Estimate(common.HexToAddress("0x000000000000000000000000000000000000dEaD"), common.HexToAddress(token), data)
+	if err != nil {
+		return nil, err
+	}
+	fee.Gas += zero.Gas
+	return fee, nil
+}
+
+// SafeApprove returns the Safe transaction(s) that allow the 1inch router (or Permit2) to spend (exactly) amount of a token.
+// if reset is true, the batch resets your allowance to zero first.
+func (client *Client) SafeApprove(token string, amount *big.Int, reset bool) (*web3.SafeBatch, error) {
+	if !client.HasMaker() {
+		return nil, errors.New("you are not trading on behalf of a smart-contract wallet")
+	}
+
+	spender, err := spender(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []web3.SafeTransaction
+	amounts := []*big.Int{amount}
+	if reset {
+		amounts = []*big.Int{new(big.Int), amount}
+	}
+	for _, amount := range amounts {
+		data, err := web3.ApproveData(spender, amount)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, web3.SafeTransaction{To: web3.Checksum(token), Value: "0", Data: hexutil.Encode(data)})
+	}
+
+	return web3.NewSafeBatch(client.ChainId, client.maker.Hex(), fmt.Sprintf("approve %s to spend %v", spender, amount), transactions...), nil
+}
+
+// Approve sends a signed ERC-20 approve transaction that allows the 1inch router (or Permit2) to spend (exactly) amount of a token.
+// if reset is true, your allowance gets reset to zero first. this is what tokens like USDT expect of you. this function
+// waits for the transaction(s) to be mined, and returns the last transaction.
+func (client *Client) Approve(token string, amount *big.Int, reset bool) (*types.Transaction, error) {
+	if reset {
+		if _, err := client.Approve(token, new(big.Int), false); err != nil {
+			return nil, err
+		}
+	}
+
+	privateKey, err := client.transactor()
+	if err != nil {
+		return nil, err
+	}
+
+	spender, err := spender(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := web3.ApproveData(spender, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := web3.Transact(privateKey, common.HexToAddress(token), data)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := web3.WaitMined(tx); err != nil {
+		return tx, err
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,60 @@
+package oneinch
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/svanas/ladder/api/web3"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/flag"
+)
+
+// a Dutch auction: the taking amount of the order decays (linearly) from takingStart to takingEnd between start and end,
+// please see https://github.com/1inch/limit-order-protocol/blob/master/contracts/extensions/DutchAuctionCalculator.sol
+type auction struct {
+	calculator  common.Address
+	start       int64 // unix timestamp
+	end         int64 // unix timestamp
+	takingStart *big.Int
+	takingEnd   *big.Int
+}
+
+// returns the Dutch auction of an order that wants to receive takerAmount, or nil if you didn't include --premium
+func (client *Client) auction(takerAmount big.Float) (*auction, error) {
+	params, err := flag.GetAuction()
+	if err != nil || params == nil {
+		return nil, err
+	}
+
+	chain, err := web3.GetChain(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+	if chain.DutchAuction == "" {
+		return nil, fmt.Errorf("--%s needs the address of the 1inch DutchAuctionCalculator on %s, please add \"dutchAuction\" to your chains.json", consts.FLAG_PREMIUM, chain.Name)
+	}
+
+	takingEnd, _ := takerAmount.Int(nil)
+	takingStart, _ := new(big.Float).Mul(&takerAmount, big.NewFloat(1+params.Premium/100)).Int(nil)
+
+	now := time.Now()
+	return &auction{
+		calculator:  common.HexToAddress(chain.DutchAuction),
+		start:       now.Unix(),
+		end:         now.Add(params.Window).Unix(),
+		takingStart: takingStart,
+		takingEnd:   takingEnd,
+	}, nil
+}
+
+// returns the address of the calculator, followed by its (ABI-encoded) arguments
+func (auction *auction) encode() []byte {
+	startTimeEndTime := new(big.Int).Or(new(big.Int).Lsh(big.NewInt(auction.start), 128), big.NewInt(auction.end))
+	out := auction.calculator.Bytes()
+	for _, word := range []*big.Int{startTimeEndTime, auction.takingStart, auction.takingEnd} {
+		out = append(out, common.LeftPadBytes(word.Bytes(), 32)...)
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package oneinch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/svanas/ladder/api/web3"
+)
+
+// returns the calldata that cancels the given orders. if all is true, every order in the series gets invalidated
+// by increasing the maker's epoch (which is cheaper), otherwise the orders are cancelled one by one.
+func cancelData(orders []Order, all bool) ([]byte, error) {
+	abi, err := abi.JSON(bytes.NewReader(apiRouterABI))
+	if err != nil {
+		return nil, err
+	}
+
+	if all {
+		return abi.Pack("increaseEpoch", big.NewInt(series))
+	}
+
+	var (
+		makerTraits []*big.Int
+		orderHashes [][32]byte
+	)
+	for _, order := range orders {
+		traits, ok := new(big.Int).SetString(trimPrefix(order.Data.MakerTraits, "0x"), 16)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %s to big.Int", order.Data.MakerTraits)
+		}
+		makerTraits = append(makerTraits, traits)
+		orderHashes = append(orderHashes, common.HexToHash(order.OrderHash))
+	}
+
+	return abi.Pack("cancelOrders", makerTraits, orderHashes)
+}
+
+// EstimateCancel returns the EIP-1559 gas estimate for cancelling the given orders
+func (client *Client) EstimateCancel(orders []Order, all bool) (*web3.Fee, error) {
+	maker, err := client.publicAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := cancelData(orders, all)
+	if err != nil {
+		return nil, err
+	}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	return web3.Estimate(maker, common.HexToAddress(router(client.ChainId)), data)
+}
+
+// SafeCancel returns the Safe transaction that invalidates the given orders
+func (client *Client) SafeCancel(orders []Order, all bool) (*web3.SafeBatch, error) {
+	if !client.HasMaker() {
+		return nil, errors.New("you are not trading on behalf of a smart-contract wallet")
+	}
+
+	data, err := cancelData(orders, all)
+	if err != nil {
+		return nil, err
+	}
+
+	return web3.NewSafeBatch(client.ChainId, client.maker.Hex(), fmt.Sprintf("cancel %d order(s)", len(orders)), web3.SafeTransaction{
+		To:    web3.Checksum(router(client.ChainId)),
+		Value: "0",
+		Data:  hexutil.Encode(data),
+	}), nil
+}
+
+// CancelOrders sends a signed transaction to the aggregation router that invalidates the given orders.
+// this function waits for the transaction to be mined.
+func (client *Client) CancelOrders(orders []Order, all bool) (*types.Transaction, error) {
+	privateKey, err := client.transactor()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := cancelData(orders, all)
+	if err != nil {
+		return nil, err
+	}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := web3.Transact(privateKey, common.HexToAddress(router(client.ChainId)), data)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := web3.WaitMined(tx); err != nil {
+		return tx, err
+	}
+
+	return tx, nil
+}
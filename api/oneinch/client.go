@@ -12,8 +12,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/svanas/ladder/api/web3"
 	consts "github.com/svanas/ladder/constants"
 	"github.com/svanas/ladder/flag"
 )
@@ -21,9 +23,26 @@ import (
 type Client struct {
 	ChainId    int64
 	privateKey []byte
+	maker      *common.Address // the maker, if different from the owner of the private key (or in lieu of a private key)
 	httpClient http.Client
 }
 
+// httpError is returned when the API responds with an unsuccessful status code
+type httpError struct {
+	statusCode int
+	message    string
+}
+
+func (err *httpError) Error() string {
+	return err.message
+}
+
+// returns true if the API responded that it doesn't know what you asked for
+func notFound(err error) bool {
+	var httpErr *httpError
+	return errors.As(err, &httpErr) && httpErr.statusCode == http.StatusNotFound
+}
+
 func (client *Client) do(request http.Request) ([]byte, error) {
 	beforeRequest()
 	defer afterRequest()
@@ -48,7 +67,7 @@ func (client *Client) do(request http.Request) ([]byte, error) {
 		}
 		var error Error
 		if json.Unmarshal(body, &error) == nil {
-			return nil, errors.New(func() string {
+			return nil, &httpError{response.StatusCode, func() string {
 				msg := strings.TrimSpace(error.Error)
 				if error.Message != "" {
 					if msg != "" {
@@ -61,9 +80,9 @@ func (client *Client) do(request http.Request) ([]byte, error) {
 					msg += error.Message
 				}
 				return msg
-			}())
+			}()}
 		} else {
-			return nil, errors.New(response.Status)
+			return nil, &httpError{response.StatusCode, response.Status}
 		}
 	}
 
@@ -101,7 +120,24 @@ func (client *Client) ecdsaPrivateKey() (*ecdsa.PrivateKey, error) {
 	return crypto.ToECDSA(client.privateKey)
 }
 
+// returns the private key that sends your transactions. returns an error if you are trading on behalf of another maker
+// (for example: a Safe), because that maker will need to send the transaction.
+func (client *Client) transactor() (*ecdsa.PrivateKey, error) {
+	if client.maker != nil {
+		return nil, fmt.Errorf("this transaction needs to be sent by %s, please use your smart-contract wallet", client.maker.Hex())
+	}
+	return client.ecdsaPrivateKey()
+}
+
+// HasMaker returns true if you are trading on behalf of another maker (for example: a Safe), that sends its own transactions
+func (client *Client) HasMaker() bool {
+	return client.maker != nil
+}
+
 func (client *Client) publicAddress() (common.Address, error) {
+	if client.maker != nil {
+		return *client.maker, nil
+	}
 	if client.privateKey == nil {
 		return [20]byte{}, fmt.Errorf("--%s cannot be empty", consts.FLAG_PRIVATE_KEY)
 	}
@@ -112,6 +148,43 @@ func (client *Client) publicAddress() (common.Address, error) {
 	return crypto.PubkeyToAddress(ecdsaPrivateKey.PublicKey), nil
 }
 
+// Prefetch reads the decimals and the symbol of these tokens, your balances, your allowances, and your epoch, in one
+// round-trip. every order that follows reads these from the cache.
+func (client *Client) Prefetch(tokens ...string) error {
+	var calls []ethereum.CallMsg
+
+	owner := ""
+	if maker, err := client.publicAddress(); err == nil {
+		owner = maker.Hex()
+		call, err := epochCall(client.ChainId, maker)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, *call)
+	}
+
+	spender, err := spender(client.ChainId)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		tokenCalls, err := web3.TokenCalls(token, owner, spender)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, tokenCalls...)
+	}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return err
+	}
+	web3.Prefetch(calls)
+
+	return nil
+}
+
 func (client *Client) GetEpoch() (*big.Int, error) {
 	maker, err := client.publicAddress()
 	if err != nil {
@@ -128,6 +201,7 @@ func ReadOnly() (*Client, error) {
 	return &Client{
 		chainId,
 		nil,
+		nil,
 		http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -145,9 +219,63 @@ func ReadWrite() (*Client, error) {
 		return nil, err
 	}
 
+	// you can sign orders on behalf of a smart-contract wallet (for example: a Safe) that you own
+	maker, err := func() (*common.Address, error) {
+		maker, err := flag.Maker()
+		if err != nil || maker == "" {
+			return nil, err
+		}
+		ecdsaPrivateKey, err := crypto.ToECDSA(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		address := common.HexToAddress(maker)
+		if address == crypto.PubkeyToAddress(ecdsaPrivateKey.PublicKey) {
+			return nil, nil
+		}
+		return &address, nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
 		chainId,
 		privateKey,
+		maker,
+		http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// ReadMaker returns a client that knows the address of the maker (--maker), but not the private key
+func ReadMaker() (*Client, error) {
+	maker, err := flag.Maker()
+	if err != nil {
+		return nil, err
+	}
+	if maker == "" {
+		return nil, fmt.Errorf("--%s cannot be empty", consts.FLAG_MAKER)
+	}
+
+	return ReadAddress(maker)
+}
+
+// ReadAddress returns a client that knows the address of an account (for example: an account in your HD wallet), but not the private key
+func ReadAddress(address string) (*Client, error) {
+	chainId, err := flag.ChainId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		chainId,
+		nil,
+		func() *common.Address {
+			address := common.HexToAddress(address)
+			return &address
+		}(),
 		http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -0,0 +1,646 @@
+package oneinch
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/flag"
+	"github.com/svanas/ladder/precision"
+)
+
+// a Fusion+ order swaps your tokens on the source chain for tokens on the destination chain. a resolver locks your tokens
+// in an escrow on the source chain, and its own tokens in an escrow on the destination chain. once both escrows have
+// been deployed, you reveal a secret (that only you know) and the resolver uses it to unlock both escrows. please see
+// https://portal.1inch.dev/documentation/apis/swap/fusion-plus/introduction
+
+const (
+	crossChainAuction  = 3 * time.Minute // the time it takes for the auction of a cross-chain order to reach its price, unless you included --decay
+	crossChainRejected = "rejected"      // the status of an order that the relayer doesn't know, because it never accepted it
+)
+
+// CrossChainOrder is a Fusion+ order, and the secrets that unlock its escrows
+type CrossChainOrder struct {
+	Order
+	SrcChainId int64     `json:"srcChainId"`
+	DstChainId int64     `json:"dstChainId"`
+	Market     string    `json:"market"`
+	Side       string    `json:"side"`
+	Secrets    []string  `json:"secrets"`            // one secret per fill. keep these to yourself until the escrows have been deployed.
+	Revealed   []int     `json:"revealed,omitempty"` // the fills we revealed the secret of
+	Created    time.Time `json:"created"`
+	Unsent     bool      `json:"unsent,omitempty"` // true if we don't know (yet) whether the relayer accepted this order
+}
+
+// CrossChainStatus is the status of a Fusion+ order, for example "pending", "executed", "expired" or "refunded"
+type CrossChainStatus struct {
+	Status string `json:"status"`
+	Fills  []struct {
+		Status string `json:"status"`
+		TxHash string `json:"txHash"`
+	} `json:"fills"`
+}
+
+// returns true if the order can still be filled (or cancelled)
+func (status *CrossChainStatus) Open() bool {
+	return status.Status == "pending"
+}
+
+// returns true if the order has been settled (or refunded, or rejected), and we no longer need to keep its secrets
+func (status *CrossChainStatus) Final() bool {
+	return slices.Contains([]string{"executed", "expired", "cancelled", "refunded", crossChainRejected}, status.Status)
+}
+
+type crossChainPreset struct {
+	SecretsCount int `json:"secretsCount"`
+}
+
+type crossChainQuote struct {
+	QuoteId        string                      `json:"quoteId"`
+	DstTokenAmount string                      `json:"dstTokenAmount"`
+	Presets        map[string]crossChainPreset `json:"presets"`
+}
+
+// the auction of a cross-chain order: the taking amount decays from auctionStartAmount to auctionEndAmount
+type customPreset struct {
+	AuctionDuration    int64  `json:"auctionDuration"` // in seconds
+	AuctionStartAmount string `json:"auctionStartAmount"`
+	AuctionEndAmount   string `json:"auctionEndAmount"`
+}
+
+// returns the location of your cross-chain orders (and their secrets), for example ~/.config/ladder/crosschain.json
+func crossChainPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ladder", "crosschain.json"), nil
+}
+
+// LoadCrossChainOrders returns every cross-chain order you placed (that hasn't been settled yet)
+func LoadCrossChainOrders() ([]CrossChainOrder, error) {
+	path, err := crossChainPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var orders []CrossChainOrder
+	if err := json.Unmarshal(data, &orders); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %v", path, err)
+	}
+	return orders, nil
+}
+
+func saveCrossChainOrders(orders []CrossChainOrder) error {
+	path, err := crossChainPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(orders, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// updates (or adds) this order
+func (order *CrossChainOrder) save() error {
+	orders, err := LoadCrossChainOrders()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(orders, func(other CrossChainOrder) bool {
+		return strings.EqualFold(other.OrderHash, order.OrderHash)
+	})
+	if i < 0 {
+		orders = append(orders, *order)
+	} else {
+		orders[i] = *order
+	}
+	return saveCrossChainOrders(orders)
+}
+
+// Forget removes this order (and its secrets) once it has been settled
+func (order *CrossChainOrder) Forget() error {
+	orders, err := LoadCrossChainOrders()
+	if err != nil {
+		return err
+	}
+	return saveCrossChainOrders(slices.DeleteFunc(orders, func(other CrossChainOrder) bool {
+		return strings.EqualFold(other.OrderHash, order.OrderHash)
+	}))
+}
+
+// GetCrossChainOrders returns the cross-chain orders you placed from this chain to dstChainId
+func (client *Client) GetCrossChainOrders(dstChainId int64) ([]CrossChainOrder, error) {
+	maker, err := client.publicAddress()
+	if err != nil {
+		return nil, err
+	}
+	orders, err := LoadCrossChainOrders()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(orders, func(order CrossChainOrder) bool {
+		return order.SrcChainId != client.ChainId || order.DstChainId != dstChainId || !strings.EqualFold(order.Data.Maker, maker.Hex())
+	}), nil
+}
+
+// returns an error if you included a flag that cross-chain orders do not support
+func crossChainFlags() error {
+	permit, err := flag.GetPermit()
+	if err != nil {
+		return err
+	}
+	if permit != flag.PERMIT_NONE {
+		return fmt.Errorf("cross-chain orders do not support --%s, please approve your asset on-chain", consts.FLAG_PERMIT)
+	}
+	receiver, err := flag.Receiver()
+	if err != nil {
+		return err
+	}
+	if receiver != "" {
+		return fmt.Errorf("cross-chain orders do not support --%s at this time", consts.FLAG_RECEIVER)
+	}
+	sender, err := flag.AllowedSender()
+	if err != nil {
+		return err
+	}
+	if sender != "" {
+		return fmt.Errorf("cross-chain orders do not support --%s, because the resolvers fill them", consts.FLAG_ALLOWED_SENDER)
+	}
+	activation, err := flag.GetActivation()
+	if err != nil {
+		return err
+	}
+	if activation != nil {
+		return errors.New("cross-chain orders do not support conditional orders at this time")
+	}
+	return nil
+}
+
+// returns n random secrets, and their hashes
+func newSecrets(n int) ([]string, []string, error) { // --> (secrets, hashes, error)
+	var secrets, hashes []string
+	for range n {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, nil, err
+		}
+		secrets = append(secrets, hexutil.Encode(secret))
+		hashes = append(hashes, crypto.Keccak256Hash(secret).Hex())
+	}
+	return secrets, hashes, nil
+}
+
+// returns the query string of a cross-chain quote
+func crossChainQuery(srcChainId, dstChainId int64, srcToken, dstToken string, amount big.Float, wallet common.Address) string {
+	return fmt.Sprintf("srcChain=%d&dstChain=%d&srcTokenAddress=%s&dstTokenAddress=%s&amount=%s&walletAddress=%s&enableEstimate=true",
+		srcChainId, dstChainId, srcToken, dstToken, precision.F2S(amount, 0), wallet.Hex())
+}
+
+// returns how much of dstToken you would receive (on the destination chain) if you were to swap amount of srcToken.
+// if preset isn't nil, the quote has your auction rather than the auction of the market.
+func (client *Client) getCrossChainQuote(query string, preset *customPreset) (*crossChainQuote, []byte, error) {
+	body, err := func() ([]byte, error) {
+		if preset == nil {
+			return client.get("/fusion-plus/quoter/v1.0/quote/receive?" + query)
+		}
+		data, err := json.Marshal(map[string]any{"customPreset": preset})
+		if err != nil {
+			return nil, err
+		}
+		return client.post("/fusion-plus/quoter/v1.0/quote/receive?"+query, data)
+	}()
+	if err != nil {
+		return nil, nil, err
+	}
+	var quote crossChainQuote
+	if err := json.Unmarshal(body, &quote); err != nil {
+		return nil, nil, err
+	}
+	return &quote, body, nil
+}
+
+// GetCrossChainQuote returns how much of dstToken (on dstChainId) you would receive if you were to swap amount of srcToken
+func (client *Client) GetCrossChainQuote(dstChainId int64, srcToken, dstToken string, amount *big.Int) (*big.Int, error) {
+	// the quoter needs a wallet, but any wallet will do
+	wallet, err := client.publicAddress()
+	if err != nil {
+		wallet = common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	}
+	quote, _, err := client.getCrossChainQuote(crossChainQuery(client.ChainId, dstChainId, srcToken, dstToken, *new(big.Float).SetInt(amount), wallet), nil)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := new(big.Int).SetString(quote.DstTokenAmount, 10)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert %s to big.Int", quote.DstTokenAmount)
+	}
+	return i, nil
+}
+
+// returns the postInteraction of an (encoded) extension. the first word of an extension has the (cumulative) offsets of
+// its fields, the postInteraction being the 8th field.
+func postInteraction(extension string) ([]byte, error) {
+	data, err := hexutil.Decode(extension)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 32 {
+		return nil, errors.New("extension is too short")
+	}
+	offset := func(field int) int {
+		if field < 0 {
+			return 0
+		}
+		return int(binary.BigEndian.Uint32(data[28-4*field : 32-4*field]))
+	}
+	start, end := 32+offset(6), 32+offset(7)
+	if start > end || end > len(data) {
+		return nil, errors.New("extension is malformed")
+	}
+	return data[start:end], nil
+}
+
+// checks that the typed data we are about to sign is the order we asked for: it spends makerAmount of makerAsset (on
+// this chain), and receives (at least) takerAmount of takerAsset on dstChainId. the takerAsset of a Fusion+ order is a
+// placeholder, the token you receive is part of the escrow arguments (hashlock, dstChainId, dstToken, deposits and
+// timelocks) that conclude the postInteraction of the order.
+func (client *Client) verifyCrossChainOrder(typedData apitypes.TypedData, extension string, dstChainId int64, maker common.Address, makerAsset, takerAsset string, makerAmount, takerAmount big.Float) error {
+	if typedData.Domain.ChainId == nil || (*big.Int)(typedData.Domain.ChainId).Int64() != client.ChainId {
+		return fmt.Errorf("order is not on chain %d", client.ChainId)
+	}
+	if !strings.EqualFold(typedData.Domain.VerifyingContract, router(client.ChainId)) {
+		return fmt.Errorf("order is not for %s, but for %s", router(client.ChainId), typedData.Domain.VerifyingContract)
+	}
+
+	message := func(key string) string {
+		return fmt.Sprint(typedData.Message[key])
+	}
+	address := func(key string) common.Address {
+		return common.HexToAddress(message(key))
+	}
+	amount := func(value string) (*big.Int, error) {
+		out, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %s to big.Int", value)
+		}
+		return out, nil
+	}
+
+	if address("maker") != maker {
+		return fmt.Errorf("order maker %s is not %s", message("maker"), maker.Hex())
+	}
+	if receiver := address("receiver"); receiver != maker && receiver != (common.Address{}) {
+		return fmt.Errorf("order receiver %s is not %s", message("receiver"), maker.Hex())
+	}
+	if address("makerAsset") != common.HexToAddress(makerAsset) {
+		return fmt.Errorf("order spends %s, expected %s", message("makerAsset"), makerAsset)
+	}
+
+	making, err := amount(message("makingAmount"))
+	if err != nil {
+		return err
+	}
+	expected, err := amount(precision.F2S(makerAmount, 0))
+	if err != nil {
+		return err
+	}
+	if making.Cmp(expected) != 0 {
+		return fmt.Errorf("order spends %v, expected %v", making, expected)
+	}
+
+	taking, err := amount(message("takingAmount"))
+	if err != nil {
+		return err
+	}
+	if expected, err = amount(precision.F2S(takerAmount, 0)); err != nil {
+		return err
+	}
+	if taking.Cmp(expected) < 0 {
+		return fmt.Errorf("order receives %v, expected at least %v", taking, expected)
+	}
+
+	data, err := postInteraction(extension)
+	if err != nil {
+		return err
+	}
+	if len(data) < 5*32 {
+		return errors.New("order does not have an escrow")
+	}
+	escrow := data[len(data)-5*32:]
+	if chainId := new(big.Int).SetBytes(escrow[32:64]); chainId.Cmp(big.NewInt(dstChainId)) != 0 {
+		return fmt.Errorf("order settles on chain %v, expected %d", chainId, dstChainId)
+	}
+	if token := common.BytesToAddress(escrow[64:96]); token != common.HexToAddress(takerAsset) {
+		return fmt.Errorf("order receives %s, expected %s", token.Hex(), takerAsset)
+	}
+
+	return nil
+}
+
+// builds and signs a Fusion+ order that spends makerAmount of makerAsset (on this chain), and receives (at least)
+// takerAmount of takerAsset on dstChainId
+func (client *Client) newCrossChainOrder(dstChainId int64, makerAsset, takerAsset string, makerAmount, takerAmount big.Float) (*CrossChainOrder, string, []string, error) { // --> (order, quote id, secret hashes, error)
+	if client.maker != nil {
+		return nil, "", nil, errors.New("cross-chain orders do not support smart-contract wallets at this time")
+	}
+	if err := crossChainFlags(); err != nil {
+		return nil, "", nil, err
+	}
+	maker, err := client.publicAddress()
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	query := crossChainQuery(client.ChainId, dstChainId, makerAsset, takerAsset, makerAmount, maker)
+
+	// the market price is where the auction starts, unless your order is above the market (or you included --premium)
+	market, _, err := client.getCrossChainQuote(query, nil)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	start, ok := new(big.Float).SetString(market.DstTokenAmount)
+	if !ok {
+		return nil, "", nil, fmt.Errorf("cannot convert %s to big.Float", market.DstTokenAmount)
+	}
+	if start.Cmp(&takerAmount) < 0 {
+		start = &takerAmount
+	}
+	duration := crossChainAuction
+	auction, err := flag.GetAuction()
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if auction != nil {
+		if premium := new(big.Float).Mul(&takerAmount, big.NewFloat(1+auction.Premium/100)); premium.Cmp(start) > 0 {
+			start = premium
+		}
+		duration = auction.Window
+	}
+
+	quote, raw, err := client.getCrossChainQuote(query, &customPreset{
+		AuctionDuration:    int64(duration.Seconds()),
+		AuctionStartAmount: precision.F2S(*start, 0),
+		AuctionEndAmount:   precision.F2S(takerAmount, 0),
+	})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// one secret per fill. an order that can be filled more than once hashes its secrets into a Merkle tree.
+	count := 1
+	if preset, ok := quote.Presets["custom"]; ok && preset.SecretsCount > 1 {
+		count = preset.SecretsCount
+	}
+	secrets, hashes, err := newSecrets(count)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// have the quoter build the order, including the escrow extension with our hashlock
+	body, err := json.Marshal(map[string]any{"quote": json.RawMessage(raw), "secretsHashList": hashes})
+	if err != nil {
+		return nil, "", nil, err
+	}
+	body, err = client.post("/fusion-plus/quoter/v1.0/quote/build?"+query+"&preset=custom", body)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	var response struct {
+		TypedData apitypes.TypedData `json:"typedData"`
+		OrderHash string             `json:"orderHash"`
+		Extension string             `json:"extension"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, "", nil, err
+	}
+
+	// check that the order hash matches the typed data, so we sign what gets submitted
+	if _, ok := response.TypedData.Types["EIP712Domain"]; !ok {
+		response.TypedData.Types["EIP712Domain"] = []apitypes.Type{
+			{Name: "name", Type: "string"},
+			{Name: "version", Type: "string"},
+			{Name: "chainId", Type: "uint256"},
+			{Name: "verifyingContract", Type: "address"},
+		}
+	}
+	hash, err := hashTypedData(response.TypedData)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if !strings.EqualFold(hash.Hex(), response.OrderHash) {
+		return nil, "", nil, fmt.Errorf("order hash %s does not match the order, expected %s", response.OrderHash, hash.Hex())
+	}
+
+	// check that the quoter built the order we asked for, before we sign it
+	if err := client.verifyCrossChainOrder(response.TypedData, response.Extension, dstChainId, maker, makerAsset, takerAsset, makerAmount, takerAmount); err != nil {
+		return nil, "", nil, err
+	}
+
+	message := func(key string) string {
+		return fmt.Sprint(response.TypedData.Message[key])
+	}
+	order := &CrossChainOrder{
+		Order: Order{
+			OrderHash: response.OrderHash,
+			Data: OrderData{
+				Salt:         message("salt"),
+				Maker:        message("maker"),
+				Receiver:     message("receiver"),
+				MakerAsset:   message("makerAsset"),
+				TakerAsset:   message("takerAsset"),
+				MakingAmount: message("makingAmount"),
+				TakingAmount: message("takingAmount"),
+				MakerTraits:  message("makerTraits"),
+				Extension:    response.Extension,
+			},
+		},
+		SrcChainId: client.ChainId,
+		DstChainId: dstChainId,
+		Secrets:    secrets,
+		Created:    time.Now(),
+	}
+
+	privateKey, err := client.ecdsaPrivateKey()
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if err := order.sign(privateKey); err != nil {
+		return nil, "", nil, err
+	}
+
+	return order, quote.QuoteId, hashes, nil
+}
+
+// PlaceCrossChainOrder submits a Fusion+ order, and keeps its secrets until its escrows have been deployed
+func (client *Client) PlaceCrossChainOrder(dstChainId int64, market, side, makerAsset, takerAsset string, makerAmount, takerAmount big.Float) error {
+	order, quoteId, hashes, err := client.newCrossChainOrder(dstChainId, makerAsset, takerAsset, makerAmount, takerAmount)
+	if err != nil {
+		return err
+	}
+	order.Market, order.Side = market, side
+
+	// keep the secrets before we submit the order, so we never lose them
+	if err := order.save(); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"order": map[string]string{
+			"salt":         order.Data.Salt,
+			"maker":        order.Data.Maker,
+			"receiver":     order.Data.Receiver,
+			"makerAsset":   order.Data.MakerAsset,
+			"takerAsset":   order.Data.TakerAsset,
+			"makingAmount": order.Data.MakingAmount,
+			"takingAmount": order.Data.TakingAmount,
+			"makerTraits":  order.Data.MakerTraits,
+		},
+		"srcChainId": client.ChainId,
+		"signature":  order.Signature,
+		"extension":  order.Data.Extension,
+		"quoteId":    quoteId,
+		"secretHashes": func() []string {
+			if len(hashes) > 1 {
+				return hashes
+			}
+			return nil
+		}(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// the relayer might have accepted the order, even if we didn't get its response. keep the secrets, and let the
+	// status of the order tell us whether the relayer accepted it.
+	if _, err := client.post("/fusion-plus/relayer/v1.0/submit", body); err != nil {
+		order.Unsent = true
+		if err := order.save(); err != nil {
+			return err
+		}
+		return fmt.Errorf("order %s might not have been submitted: %v", order.OrderHash, err)
+	}
+
+	return nil
+}
+
+// ValidateCrossChainOrder has the quoter build the order, and signs it. it does not submit the order.
+func (client *Client) ValidateCrossChainOrder(dstChainId int64, market, side, makerAsset, takerAsset string, makerAmount, takerAmount big.Float) error {
+	_, _, _, err := client.newCrossChainOrder(dstChainId, makerAsset, takerAsset, makerAmount, takerAmount)
+	return err
+}
+
+// returns a client that reads (and reveals the secrets of) this order, but cannot sign anything
+func (order *CrossChainOrder) client() *Client {
+	return &Client{
+		order.SrcChainId,
+		nil,
+		nil,
+		http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// GetStatus returns the cross-chain status of this order. if we don't know whether the relayer accepted this order, the
+// status tells us: the relayer either knows the order (and accepted it), or the order has been rejected.
+func (order *CrossChainOrder) GetStatus() (*CrossChainStatus, error) {
+	body, err := order.client().get("/fusion-plus/orders/v1.0/order/status/" + order.OrderHash)
+	if err != nil {
+		if order.Unsent && notFound(err) {
+			return &CrossChainStatus{Status: crossChainRejected}, nil
+		}
+		return nil, err
+	}
+	var status CrossChainStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, err
+	}
+	if order.Unsent {
+		order.Unsent = false
+		if err := order.save(); err != nil {
+			return nil, err
+		}
+	}
+	return &status, nil
+}
+
+// returns the fills (of this order) whose escrows have been deployed on both chains
+func (order *CrossChainOrder) readyToAcceptSecret() ([]int, error) {
+	body, err := order.client().get("/fusion-plus/orders/v1.0/order/ready-to-accept-secret-fills/" + order.OrderHash)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Fills []struct {
+			Idx int `json:"idx"`
+		} `json:"fills"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	var out []int
+	for _, fill := range response.Fills {
+		out = append(out, fill.Idx)
+	}
+	return out, nil
+}
+
+// Reveal submits the secret of every fill whose escrows have been deployed, so the resolver can unlock them. returns
+// the number of secrets we revealed. if dryRun is true, returns the number of secrets we would have revealed.
+func (order *CrossChainOrder) Reveal(dryRun bool) (int, error) {
+	fills, err := order.readyToAcceptSecret()
+	if err != nil {
+		return 0, err
+	}
+	var out int
+	for _, idx := range fills {
+		if slices.Contains(order.Revealed, idx) {
+			continue
+		}
+		if idx < 0 || idx >= len(order.Secrets) {
+			return out, fmt.Errorf("order %s does not have a secret for fill %d", order.OrderHash, idx)
+		}
+		out++
+		if dryRun {
+			continue
+		}
+		body, err := json.Marshal(map[string]string{"secret": order.Secrets[idx], "orderHash": order.OrderHash})
+		if err != nil {
+			return out, err
+		}
+		if _, err := order.client().post("/fusion-plus/relayer/v1.0/submit/secret", body); err != nil {
+			return out, err
+		}
+		order.Revealed = append(order.Revealed, idx)
+		if err := order.save(); err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package oneinch
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestVerifyCrossChainOrder(t *testing.T) {
+	const (
+		maker       = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+		makerAsset  = "0xaf88d065e77c8cC2239327C5EDb3A432268e5831"
+		takerAsset  = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+		placeholder = "0xDa0000d4000015A526378bB6faFc650Cea5966F8"
+	)
+
+	// the escrow arguments that conclude the postInteraction: hashlock, dstChainId, dstToken, deposits and timelocks
+	extension := func(dstChainId int64, dstToken string) string {
+		escrow := make([]byte, 5*32)
+		copy(escrow[32:64], common.LeftPadBytes(big.NewInt(dstChainId).Bytes(), 32))
+		copy(escrow[64:96], common.LeftPadBytes(common.HexToAddress(dstToken).Bytes(), 32))
+		return encodeInteractions([][]byte{{}, {}, {1, 2, 3}, {1, 2, 3}, {}, {}, {}, append([]byte{4, 5, 6}, escrow...)})
+	}
+
+	typedData := func(chainId int64, verifyingContract string, message map[string]string) apitypes.TypedData {
+		out := apitypes.TypedData{
+			Domain: apitypes.TypedDataDomain{
+				ChainId:           math.NewHexOrDecimal256(chainId),
+				VerifyingContract: verifyingContract,
+			},
+			Message: apitypes.TypedDataMessage{
+				"maker":        maker,
+				"receiver":     "0x0000000000000000000000000000000000000000",
+				"makerAsset":   makerAsset,
+				"takerAsset":   placeholder,
+				"makingAmount": "1000000",
+				"takingAmount": "990000",
+			},
+		}
+		for key, value := range message {
+			out.Message[key] = value
+		}
+		return out
+	}
+
+	client := &Client{ChainId: 42161}
+	for _, test := range []struct {
+		name      string
+		typedData apitypes.TypedData
+		extension string
+		err       string
+	}{
+		{"ok", typedData(42161, apiRouter, nil), extension(1, takerAsset), ""},
+		{"receiver is maker", typedData(42161, apiRouter, map[string]string{"receiver": strings.ToLower(maker)}), extension(1, takerAsset), ""},
+		{"more than the minimum", typedData(42161, apiRouter, map[string]string{"takingAmount": "995000"}), extension(1, takerAsset), ""},
+		{"wrong chain", typedData(1, apiRouter, nil), extension(1, takerAsset), "not on chain"},
+		{"wrong contract", typedData(42161, maker, nil), extension(1, takerAsset), "is not for"},
+		{"wrong maker", typedData(42161, apiRouter, map[string]string{"maker": takerAsset}), extension(1, takerAsset), "order maker"},
+		{"wrong receiver", typedData(42161, apiRouter, map[string]string{"receiver": takerAsset}), extension(1, takerAsset), "order receiver"},
+		{"wrong maker asset", typedData(42161, apiRouter, map[string]string{"makerAsset": takerAsset}), extension(1, takerAsset), "order spends"},
+		{"wrong making amount", typedData(42161, apiRouter, map[string]string{"makingAmount": "2000000"}), extension(1, takerAsset), "order spends"},
+		{"below the minimum", typedData(42161, apiRouter, map[string]string{"takingAmount": "989999"}), extension(1, takerAsset), "at least"},
+		{"wrong destination chain", typedData(42161, apiRouter, nil), extension(10, takerAsset), "settles on chain"},
+		{"wrong taker asset", typedData(42161, apiRouter, nil), extension(1, makerAsset), "order receives"},
+		{"no escrow", typedData(42161, apiRouter, nil), encodeInteractions([][]byte{{}, {}, {}, {}, {}, {}, {}, {1}}), "escrow"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := client.verifyCrossChainOrder(test.typedData, test.extension, 1, common.HexToAddress(maker), makerAsset, takerAsset, *big.NewFloat(1000000), *big.NewFloat(990000))
+			if test.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Fatalf("expected an error that contains %q, got %v", test.err, err)
+			}
+		})
+	}
+}
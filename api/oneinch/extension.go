@@ -15,10 +15,13 @@ type Extension struct {
 	receiver      common.Address
 	integratorFee IntegratorFee
 	resolverFee   ResolverFee
+	makerPermit   *Permit
+	predicate     predicate
+	auction       *auction
 }
 
-func newExtension(receiver common.Address, integratorFee IntegratorFee, resolverFee ResolverFee) *Extension {
-	return &Extension{receiver, integratorFee, resolverFee}
+func newExtension(receiver common.Address, integratorFee IntegratorFee, resolverFee ResolverFee, makerPermit *Permit, predicate predicate, auction *auction) *Extension {
+	return &Extension{receiver, integratorFee, resolverFee, makerPermit, predicate, auction}
 }
 
 func trimPrefix(s, prefix string) string {
@@ -214,13 +217,26 @@ func (e *Extension) encode() (string, error) {
 		makingTaking = padded
 	}
 
+	// the fee extension hands the amount calculation over to the Dutch auction calculator (if any) that follows the whitelist
+	if e.auction != nil {
+		if len(whitelist) == 0 {
+			makingTaking = append(makingTaking, 0) // the size of the (empty) whitelist
+		}
+		makingTaking = append(makingTaking, e.auction.encode()...)
+	}
+
+	var makerPermit []byte
+	if e.makerPermit != nil {
+		makerPermit = e.makerPermit.encode()
+	}
+
 	interactions := [][]byte{
 		{},                                       // MakerAssetSuffix (empty)
 		{},                                       // TakerAssetSuffix (empty)
 		append(extensionTarget, makingTaking...), // MakingAmountData
 		append(extensionTarget, makingTaking...), // TakingAmountData (same as making)
-		{},                                       // Predicate (empty)
-		{},                                       // MakerPermit (empty)
+		e.predicate,                              // Predicate (empty unless the order needs to activate first)
+		makerPermit,                              // MakerPermit (empty unless we have a permit)
 		{},                                       // PreInteractionData (empty)
 		append(extensionTarget, postInteractionData...), // PostInteractionData
 	}
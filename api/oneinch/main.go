@@ -14,6 +14,7 @@ import (
 const (
 	apiURL    = "https://api.1inch.com"
 	apiRouter = "0x111111125421cA6dc452d289314280a0f8842A65"
+	series    = 0 // the nonce series of every order we place
 )
 
 //go:embed 1inch.api.key
@@ -22,6 +23,30 @@ var apiKey string
 //go:embed router.abi.json
 var apiRouterABI []byte
 
+// returns the address of the aggregation router on this chain, which is apiRouter on (almost) every chain
+func router(chainId int64) string {
+	if chain, err := web3.GetChain(chainId); err == nil && chain.Router != "" {
+		return chain.Router
+	}
+	return apiRouter
+}
+
+// returns the call that reads the maker's epoch
+func epochCall(chainId int64, maker common.Address) (*ethereum.CallMsg, error) {
+	abi, err := abi.JSON(bytes.NewReader(apiRouterABI))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := abi.Pack("epoch", maker, big.NewInt(series))
+	if err != nil {
+		return nil, err
+	}
+
+	to := common.HexToAddress(router(chainId))
+	return &ethereum.CallMsg{To: &to, Data: data}, nil
+}
+
 func getEpoch(chainId int64, maker common.Address) (*big.Int, error) {
 	web3, err := web3.New(chainId)
 	if err != nil {
@@ -33,13 +58,12 @@ func getEpoch(chainId int64, maker common.Address) (*big.Int, error) {
 		return nil, err
 	}
 
-	data, err := abi.Pack("epoch", maker, big.NewInt(0))
+	call, err := epochCall(chainId, maker)
 	if err != nil {
 		return nil, err
 	}
 
-	to := common.HexToAddress(apiRouter)
-	response, err := web3.Call(ethereum.CallMsg{To: &to, Data: data}, nil)
+	response, err := web3.Call(*call, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func newMakerTraits(epoch big.Int, expiry int64) *MakerTraits {
 		AllowedSender:       "0x0000000000000000000000000000000000000000",
 		Expiry:              expiry,
 		Nonce:               epoch.Int64(),
-		Series:              0,
+		Series:              series,
 		NeedPostinteraction: true,
 		NeedPreinteraction:  false,
 		NeedEpochCheck:      true,
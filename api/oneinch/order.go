@@ -1,18 +1,61 @@
 package oneinch
 
 import (
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/svanas/ladder/api/web3"
 	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/flag"
 	"github.com/svanas/ladder/precision"
 	"math/big"
+	"strings"
+	"sync"
 	"time"
 )
 
+// the making amounts of the orders we built in this process, per chain, maker and maker asset. a ladder spends the
+// same allowance and the same balance many times over, so every order needs them to cover the orders before it.
+var (
+	spent      = make(map[string]*big.Int)
+	spentMutex sync.Mutex
+)
+
+func spentKey(chainId int64, maker common.Address, makerAsset string) string {
+	return fmt.Sprintf("%d:%s:%s", chainId, maker.Hex(), strings.ToLower(makerAsset))
+}
+
+// returns the making amount of the orders we built (in this process) so far, plus makerAmount
+func (client *Client) spending(maker common.Address, makerAsset string, makerAmount big.Float) *big.Float {
+	spentMutex.Lock()
+	defer spentMutex.Unlock()
+	out := new(big.Float).Set(&makerAmount)
+	if amount, ok := spent[spentKey(client.ChainId, maker, makerAsset)]; ok {
+		out.Add(out, new(big.Float).SetInt(amount))
+	}
+	return out
+}
+
+// adds the making amount of an order we built (and posted, validated, or planned) to the amount we spent
+func (client *Client) spend(order *Order) error {
+	amount, err := order.Data.GetMakerAmount()
+	if err != nil {
+		return err
+	}
+	spentMutex.Lock()
+	defer spentMutex.Unlock()
+	key := spentKey(client.ChainId, common.HexToAddress(order.Data.Maker), order.Data.MakerAsset)
+	if _, ok := spent[key]; !ok {
+		spent[key] = new(big.Int)
+	}
+	spent[key].Add(spent[key], amount)
+	return nil
+}
+
 type OrderData struct {
 	Salt         string `json:"salt"`         // the highest 96 bits represent salt, and the lowest 160 bit represent extension hash.
 	Maker        string `json:"maker"`        // the maker’s address
@@ -41,10 +84,36 @@ func (order *OrderData) GetTakerAmount() (*big.Int, error) {
 	return i, nil
 }
 
+// returns the time the order will expire, as encoded in the maker traits. returns zero if the order doesn't expire.
+func (order *OrderData) GetExpiry() (time.Time, error) {
+	traits, ok := new(big.Int).SetString(trimPrefix(order.MakerTraits, "0x"), 16)
+	if !ok {
+		return time.Time{}, fmt.Errorf("cannot convert %s to big.Int", order.MakerTraits)
+	}
+	expiry := new(big.Int).And(new(big.Int).Rsh(traits, 80), big.NewInt(1<<40-1)) // 40 bits
+	if expiry.Sign() == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(expiry.Int64(), 0), nil
+}
+
 type Order struct {
-	Signature string    `json:"signature"`
-	OrderHash string    `json:"orderHash"`
-	Data      OrderData `json:"data"`
+	Signature            string    `json:"signature"`
+	OrderHash            string    `json:"orderHash"`
+	Data                 OrderData `json:"data"`
+	RemainingMakerAmount string    `json:"remainingMakerAmount,omitempty"` // the making amount that hasn't been filled yet (read-only)
+}
+
+// returns the making amount that hasn't been filled yet
+func (order *Order) GetRemainingAmount() (*big.Int, error) {
+	if order.RemainingMakerAmount == "" {
+		return order.Data.GetMakerAmount()
+	}
+	i, ok := new(big.Int).SetString(order.RemainingMakerAmount, 10)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert %s to big.Int", order.RemainingMakerAmount)
+	}
+	return i, nil
 }
 
 func (client *Client) GetOrders() ([]Order, error) {
@@ -83,46 +152,95 @@ func (client *Client) GetOrders() ([]Order, error) {
 	return output, nil
 }
 
-func (client *Client) PlaceOrder(makerAsset, takerAsset string, makerAmount, takerAmount big.Float, nonce big.Int, days int) error {
+// builds (but doesn't sign) a limit order. if unwrap is true, the (wrapped native) taker asset is unwrapped when the order gets filled.
+func (client *Client) buildOrder(makerAsset, takerAsset string, makerAmount, takerAmount big.Float, nonce big.Int, days int, permit *Permit, unwrap bool) (*Order, error) {
 	maker, err := client.publicAddress()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// an EIP-2612 permit replaces the allowance, a Permit2 permit needs an allowance for Permit2
+	if !permit.appliesTo(makerAsset) {
+		permit = nil
+	}
+	spender := router(client.ChainId)
+	if permit != nil && permit.permit2 {
+		spender = web3.Permit2
 	}
 
-	// get the allowance, exit early when the 1inch router hasn't been approved
+	// this order, and the orders before it, spend the same allowance and the same balance
+	spending := client.spending(maker, makerAsset, makerAmount)
+
+	// get the allowance, exit early when the 1inch router (or Permit2) hasn't been approved
 	web3, err := web3.New(client.ChainId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if permit == nil || permit.permit2 {
+		allowance, err := web3.GetAllowance(makerAsset, maker.Hex(), spender)
+		if err != nil {
+			return nil, err
+		}
+		if new(big.Float).SetInt(allowance).Cmp(spending) < 0 {
+			return nil, fmt.Errorf("please approve %s with the approve command, or include --auto-approve", func() string {
+				if symbol, err := web3.GetSymbol(makerAsset); err == nil && symbol != "" {
+					return symbol
+				}
+				return makerAsset
+			}())
+		}
 	}
-	allowance, err := web3.GetAllowance(makerAsset, maker.Hex(), apiRouter)
+
+	// exit early when the maker doesn't have enough of the maker asset
+	balance, err := web3.GetBalance(makerAsset, maker.Hex())
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if new(big.Float).SetInt(allowance).Cmp(&makerAmount) < 0 {
-		return fmt.Errorf("please approve %s on https://1inch.com/pro?mode=limit&pair=%d:%s-%s", func() string {
+	if new(big.Float).SetInt(balance).Cmp(spending) < 0 {
+		return nil, fmt.Errorf("%s does not have enough %s", maker.Hex(), func() string {
 			if symbol, err := web3.GetSymbol(makerAsset); err == nil && symbol != "" {
 				return symbol
 			}
 			return makerAsset
-		}(), client.ChainId, makerAsset, takerAsset)
+		}())
 	}
 
 	// get calculated making amount on trading pair by provided amount
 	resolverFee, err := client.getFeeInfo(makerAsset, takerAsset, makerAmount, takerAmount)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// the proceeds go to the maker, unless the user specified another receiver
+	receiver := maker
+	if address, err := flag.Receiver(); err != nil {
+		return nil, err
+	} else if address != "" {
+		receiver = common.HexToAddress(address)
+	}
+
+	// the order can only be filled once it activates, if the user specified when
+	predicate, err := client.predicate()
+	if err != nil {
+		return nil, err
+	}
+
+	// the order starts at a premium that decays over time, if the user asked for a Dutch auction
+	auction, err := client.auction(takerAmount)
+	if err != nil {
+		return nil, err
 	}
 
 	// build the order extension and encode it
-	extension, err := newExtension(maker, *getIntegratorFee(), *resolverFee).encode()
+	extension, err := newExtension(receiver, *getIntegratorFee(), *resolverFee, permit, predicate, auction).encode()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// compute the salt. the highest 96 bits represent salt, and the lowest 160 bit represent extension hash
 	salt, err := generateSalt(extension, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	expiry := func() time.Duration {
@@ -132,20 +250,71 @@ func (client *Client) PlaceOrder(makerAsset, takerAsset string, makerAmount, tak
 		return consts.THREE_YEARS
 	}()
 
+	makerTraits := newMakerTraits(nonce, time.Now().Add(expiry).Unix())
+	makerTraits.ShouldUsePermit2 = permit != nil && permit.permit2
+	makerTraits.ShouldUnwrapWeth = unwrap
+	// a private order can only be filled by the allowed sender
+	if address, err := flag.AllowedSender(); err != nil {
+		return nil, err
+	} else if address != "" {
+		makerTraits.AllowedSender = address
+	}
+
 	orderData := OrderData{
 		Salt:         fmt.Sprintf("%d", salt),
 		Maker:        maker.Hex(),
-		Receiver:     resolverFee.ExtensionAddress,
+		Receiver:     resolverFee.ExtensionAddress, // the fee extension takes its fee, and forwards the rest to the extension's receiver
 		MakerAsset:   makerAsset,
 		TakerAsset:   takerAsset,
 		MakingAmount: precision.F2S(makerAmount, 0),
 		TakingAmount: precision.F2S(takerAmount, 0),
-		MakerTraits:  newMakerTraits(nonce, time.Now().Add(expiry).Unix()).encode(),
+		MakerTraits:  makerTraits.encode(),
 		Extension:    extension,
 	}
 
-	// construct the ERC-712 message
-	typedData := apitypes.TypedData{
+	// hash the ERC-712 message
+	orderHash, err := orderData.hash(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	// construct the (unsigned) limit order
+	return &Order{
+		OrderHash: orderHash.Hex(),
+		Data:      orderData,
+	}, nil
+}
+
+// builds and signs a limit order
+func (client *Client) newOrder(makerAsset, takerAsset string, makerAmount, takerAmount big.Float, nonce big.Int, days int, permit *Permit, unwrap bool) (*Order, error) {
+	order, err := client.buildOrder(makerAsset, takerAsset, makerAmount, takerAmount, nonce, days, permit, unwrap)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := client.ecdsaPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// sign on behalf of a smart-contract wallet, if you are trading on behalf of one
+	if client.maker != nil {
+		if err := client.signSafe(order, privateKey); err != nil {
+			return nil, err
+		}
+		return order, nil
+	}
+
+	if err := order.sign(privateKey); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// returns the ERC-712 typed data of the order
+func (order *OrderData) typedData(chainId int64) apitypes.TypedData {
+	return apitypes.TypedData{
 		Types: apitypes.Types{
 			"EIP712Domain": []apitypes.Type{
 				{Name: "name", Type: "string"},
@@ -168,41 +337,46 @@ func (client *Client) PlaceOrder(makerAsset, takerAsset string, makerAmount, tak
 		Domain: apitypes.TypedDataDomain{
 			Name:              "1inch Aggregation Router",
 			Version:           "6",
-			ChainId:           math.NewHexOrDecimal256(client.ChainId),
-			VerifyingContract: apiRouter,
+			ChainId:           math.NewHexOrDecimal256(chainId),
+			VerifyingContract: router(chainId),
 		},
 		Message: apitypes.TypedDataMessage{
-			"salt":         orderData.Salt,
-			"maker":        orderData.Maker,
-			"receiver":     orderData.Receiver,
-			"makerAsset":   orderData.MakerAsset,
-			"takerAsset":   orderData.TakerAsset,
-			"makingAmount": orderData.MakingAmount,
-			"takingAmount": orderData.TakingAmount,
-			"makerTraits":  orderData.MakerTraits,
+			"salt":         order.Salt,
+			"maker":        order.Maker,
+			"receiver":     order.Receiver,
+			"makerAsset":   order.MakerAsset,
+			"takerAsset":   order.TakerAsset,
+			"makingAmount": order.MakingAmount,
+			"takingAmount": order.TakingAmount,
+			"makerTraits":  order.MakerTraits,
 		},
 	}
+}
 
-	// hash the ERC-712 message
+// returns the hash of an ERC-712 message
+func hashTypedData(typedData apitypes.TypedData) (common.Hash, error) {
 	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
 	if err != nil {
-		return err
+		return common.Hash{}, err
 	}
 	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
 	if err != nil {
-		return err
+		return common.Hash{}, err
 	}
 
 	// prepare the data for signing
 	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
-	challengeHash := crypto.Keccak256Hash(rawData)
+	return crypto.Keccak256Hash(rawData), nil
+}
 
-	// sign the challenge hash
-	privateKey, err := client.ecdsaPrivateKey()
-	if err != nil {
-		return err
-	}
-	signature, err := crypto.Sign(challengeHash.Bytes(), privateKey)
+// returns the ERC-712 hash of the order (aka the order hash)
+func (order *OrderData) hash(chainId int64) (common.Hash, error) {
+	return hashTypedData(order.typedData(chainId))
+}
+
+// signs the order hash
+func (order *Order) sign(privateKey *ecdsa.PrivateKey) error {
+	signature, err := crypto.Sign(common.HexToHash(order.OrderHash).Bytes(), privateKey)
 	if err != nil {
 		return err
 	}
@@ -210,12 +384,17 @@ func (client *Client) PlaceOrder(makerAsset, takerAsset string, makerAmount, tak
 	// add 27 to `v` value (last byte)
 	signature[64] += 27
 
-	// construct the limit order
-	body, err := json.Marshal(&Order{
-		OrderHash: challengeHash.Hex(),
-		Signature: fmt.Sprintf("0x%x", signature),
-		Data:      orderData,
-	})
+	order.Signature = fmt.Sprintf("0x%x", signature)
+	return nil
+}
+
+func (client *Client) PlaceOrder(makerAsset, takerAsset string, makerAmount, takerAmount big.Float, nonce big.Int, days int, permit *Permit, unwrap bool) error {
+	order, err := client.newOrder(makerAsset, takerAsset, makerAmount, takerAmount, nonce, days, permit, unwrap)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(order)
 	if err != nil {
 		return err
 	}
@@ -225,5 +404,14 @@ func (client *Client) PlaceOrder(makerAsset, takerAsset string, makerAmount, tak
 		return err
 	}
 
-	return nil
+	return client.spend(order)
+}
+
+// ValidateOrder checks the allowance, fetches the fee info, and signs the order. it does not post the order.
+func (client *Client) ValidateOrder(makerAsset, takerAsset string, makerAmount, takerAmount big.Float, nonce big.Int, days int, permit *Permit, unwrap bool) error {
+	order, err := client.newOrder(makerAsset, takerAsset, makerAmount, takerAmount, nonce, days, permit, unwrap)
+	if err != nil {
+		return err
+	}
+	return client.spend(order)
 }
@@ -0,0 +1,82 @@
+package oneinch
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/svanas/ladder/api/web3"
+	"github.com/svanas/ladder/flag"
+)
+
+// Permit is a signed EIP-2612 or Uniswap Permit2 permit, embedded in the order extension so the router can spend
+// the maker asset without a prior on-chain approval
+type Permit struct {
+	token   string // the maker asset
+	data    []byte // the abi-encoded arguments of the permit call
+	permit2 bool   // true if this is a Uniswap Permit2 permit, otherwise EIP-2612
+}
+
+// returns the MakerPermit interaction: the token address followed by the permit call arguments
+func (permit *Permit) encode() []byte {
+	return append(common.HexToAddress(permit.token).Bytes(), permit.data...)
+}
+
+// returns true if this permit applies to the maker asset
+func (permit *Permit) appliesTo(makerAsset string) bool {
+	return permit != nil && strings.EqualFold(permit.token, makerAsset)
+}
+
+// returns the contract that needs an allowance before the router can spend your tokens
+func spender(chainId int64) (string, error) {
+	permit, err := flag.GetPermit()
+	if err != nil {
+		return "", err
+	}
+	if permit == flag.PERMIT_PERMIT2 {
+		return web3.Permit2, nil
+	}
+	return router(chainId), nil
+}
+
+// SignPermit signs a permit that allows the 1inch router to spend (exactly) amount of a token until deadline.
+// returns nil if the user didn't ask for a permit.
+func (client *Client) SignPermit(token string, amount *big.Int, deadline int64) (*Permit, error) {
+	kind, err := flag.GetPermit()
+	if err != nil || kind == flag.PERMIT_NONE {
+		return nil, err
+	}
+
+	// a smart-contract wallet cannot sign a permit, it will need to approve the router instead
+	if client.maker != nil {
+		return nil, fmt.Errorf("cannot sign a permit on behalf of %s, please approve from your smart-contract wallet", client.maker.Hex())
+	}
+
+	privateKey, err := client.ecdsaPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == flag.PERMIT_PERMIT2 {
+		if amount.BitLen() > 160 {
+			return nil, fmt.Errorf("amount %v exceeds the Permit2 maximum", amount)
+		}
+		data, err := web3.Permit2(privateKey, client.ChainId, token, router(client.ChainId), amount, deadline)
+		if err != nil {
+			return nil, err
+		}
+		return &Permit{token, data, true}, nil
+	}
+
+	data, err := web3.Permit(privateKey, client.ChainId, token, router(client.ChainId), amount, deadline)
+	if err != nil {
+		return nil, err
+	}
+	return &Permit{token, data, false}, nil
+}
@@ -0,0 +1,221 @@
+package oneinch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/svanas/ladder/api/web3"
+)
+
+// Plan is a ladder of limit orders that gets built on an internet-connected machine, signed on an air-gapped machine,
+// and then posted from an internet-connected machine again.
+type Plan struct {
+	ChainId   int64          `json:"chainId"`
+	Owners    []string       `json:"owners,omitempty"`    // the owners of the maker, if the maker is a Safe
+	Threshold int            `json:"threshold,omitempty"` // the number of owner signatures, if the maker is a Safe
+	Orders    []PlannedOrder `json:"orders"`
+}
+
+type PlannedOrder struct {
+	Order
+	TypedData   apitypes.TypedData  `json:"typedData"`             // the ERC-712 message that gets signed
+	SafeMessage *apitypes.TypedData `json:"safeMessage,omitempty"` // the ERC-712 message every owner signs, if the maker is a Safe
+	Signatures  map[string]string   `json:"signatures,omitempty"`  // the owner signatures, if the maker is a Safe
+}
+
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %v", path, err)
+	}
+	return &plan, nil
+}
+
+func (plan *Plan) Save(path string) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// PlanOrder builds (but doesn't sign) a limit order
+func (client *Client) PlanOrder(makerAsset, takerAsset string, makerAmount, takerAmount big.Float, nonce big.Int, days int, permit *Permit, unwrap bool) (*PlannedOrder, error) {
+	order, err := client.buildOrder(makerAsset, takerAsset, makerAmount, takerAmount, nonce, days, permit, unwrap)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.spend(order); err != nil {
+		return nil, err
+	}
+	return &PlannedOrder{Order: *order, TypedData: order.Data.typedData(client.ChainId)}, nil
+}
+
+// Add adds an order to the plan. if the maker is a Safe, the order gets the message its owners will need to sign.
+func (plan *Plan) Add(order *PlannedOrder) {
+	if plan.safe() {
+		message := web3.SafeMessage(plan.ChainId, order.Data.Maker, common.HexToHash(order.OrderHash))
+		order.SafeMessage = &message
+	}
+	plan.Orders = append(plan.Orders, *order)
+}
+
+// returns true if the order has been signed
+func (order *PlannedOrder) Signed() bool {
+	return order.Signature != ""
+}
+
+// checks that the order hash matches both the order data and the typed data, so the signer signs what gets posted
+func (order *PlannedOrder) verifyHash(chainId int64) error {
+	dataHash, err := order.Data.hash(chainId)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(dataHash.Hex(), order.OrderHash) {
+		return fmt.Errorf("order %s does not match its order data", order.OrderHash)
+	}
+	typedDataHash, err := hashTypedData(order.TypedData)
+	if err != nil {
+		return err
+	}
+	if typedDataHash != dataHash {
+		return fmt.Errorf("order %s does not match its typed data", order.OrderHash)
+	}
+	return nil
+}
+
+// returns the address that signed the hash
+func recoverSigner(hash common.Hash, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("%s is not a valid signature", signature)
+	}
+	// subtract 27 from `v` value (last byte)
+	sig[64] -= 27
+	publicKey, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// checks that the signature has been signed by the maker
+func (order *PlannedOrder) verifySignature() error {
+	signer, err := recoverSigner(common.HexToHash(order.OrderHash), order.Signature)
+	if err != nil {
+		return fmt.Errorf("order %s has an invalid signature", order.OrderHash)
+	}
+	if signer != common.HexToAddress(order.Data.Maker) {
+		return fmt.Errorf("order %s has not been signed by maker %s", order.OrderHash, order.Data.Maker)
+	}
+	return nil
+}
+
+// Verify checks every order hash against its order data, and every signature (if any) against the maker
+func (plan *Plan) Verify() error {
+	if len(plan.Orders) == 0 {
+		return errors.New("plan does not have any orders")
+	}
+	for _, order := range plan.Orders {
+		if err := order.verifyHash(plan.ChainId); err != nil {
+			return err
+		}
+		if plan.safe() {
+			if err := plan.verifySafe(&order); err != nil {
+				return err
+			}
+		} else if order.Signed() {
+			if err := order.verifySignature(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Sign signs every order in the plan. this function doesn't need an internet connection.
+func (plan *Plan) Sign(privateKey []byte) error {
+	if err := plan.Verify(); err != nil {
+		return err
+	}
+
+	ecdsaPrivateKey, err := crypto.ToECDSA(privateKey)
+	if err != nil {
+		return err
+	}
+	// every owner of a Safe signs in turn
+	if plan.safe() {
+		return plan.signSafe(ecdsaPrivateKey)
+	}
+
+	signer := crypto.PubkeyToAddress(ecdsaPrivateKey.PublicKey)
+
+	for i := range plan.Orders {
+		order := &plan.Orders[i]
+		if common.HexToAddress(order.Data.Maker) != signer {
+			return fmt.Errorf("order %s belongs to maker %s, not to %s", order.OrderHash, order.Data.Maker, signer.Hex())
+		}
+		if err := order.sign(ecdsaPrivateKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Submit posts every (signed) order in the plan to the orderbook
+func (plan *Plan) Submit() error {
+	if err := plan.Verify(); err != nil {
+		return err
+	}
+
+	for _, order := range plan.Orders {
+		if !order.Signed() {
+			return fmt.Errorf("order %s has not been signed", order.OrderHash)
+		}
+	}
+
+	if plan.safe() {
+		if err := plan.validateSafe(); err != nil {
+			return err
+		}
+	}
+
+	client := &Client{
+		plan.ChainId,
+		nil,
+		nil,
+		http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+
+	for _, order := range plan.Orders {
+		body, err := json.Marshal(order.Order)
+		if err != nil {
+			return err
+		}
+		if _, err := client.post(fmt.Sprintf("/orderbook/v4.1/%d", plan.ChainId), body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
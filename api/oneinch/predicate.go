@@ -0,0 +1,190 @@
+package oneinch
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/svanas/ladder/api/web3"
+	"github.com/svanas/ladder/flag"
+)
+
+// a predicate is the calldata of a call on the router that returns true or false. an order with a predicate can only
+// be filled while its predicate is true, please see
+// https://github.com/1inch/limit-order-protocol/blob/master/contracts/helpers/PredicateHelper.sol
+type predicate []byte
+
+// returns the calldata that has the router call a contract and return the (uint256) result
+func arbitraryStaticCall(target common.Address, data []byte) ([]byte, error) {
+	abi, err := abi.JSON(bytes.NewReader(apiRouterABI))
+	if err != nil {
+		return nil, err
+	}
+	return abi.Pack("arbitraryStaticCall", target, data)
+}
+
+// returns a predicate that is true if the result of the call is less than value
+func lt(value *big.Int, call []byte) (predicate, error) {
+	abi, err := abi.JSON(bytes.NewReader(apiRouterABI))
+	if err != nil {
+		return nil, err
+	}
+	return abi.Pack("lt", value, call)
+}
+
+// returns a predicate that is true if the result of the call is greater than value
+func gt(value *big.Int, call []byte) (predicate, error) {
+	abi, err := abi.JSON(bytes.NewReader(apiRouterABI))
+	if err != nil {
+		return nil, err
+	}
+	return abi.Pack("gt", value, call)
+}
+
+// returns the offsets and the concatenated predicates that the and/or predicates expect. every offset is a 32-bit
+// cumulative length, the first predicate in the lowest bits.
+func combine(predicates []predicate) (*big.Int, []byte, error) {
+	if len(predicates) > 8 {
+		return nil, nil, fmt.Errorf("cannot combine more than 8 predicates, got %d", len(predicates))
+	}
+	var (
+		offsets = new(big.Int)
+		data    []byte
+	)
+	for i, condition := range predicates {
+		data = append(data, condition...)
+		offsets.Or(offsets, new(big.Int).Lsh(big.NewInt(int64(len(data))), uint(32*i)))
+	}
+	return offsets, data, nil
+}
+
+// returns a predicate that is true if every predicate is true
+func and(predicates ...predicate) (predicate, error) {
+	offsets, data, err := combine(predicates)
+	if err != nil {
+		return nil, err
+	}
+	abi, err := abi.JSON(bytes.NewReader(apiRouterABI))
+	if err != nil {
+		return nil, err
+	}
+	return abi.Pack("and", offsets, data)
+}
+
+// returns a predicate that is true if one (or more) of the predicates is true
+func or(predicates ...predicate) (predicate, error) {
+	offsets, data, err := combine(predicates)
+	if err != nil {
+		return nil, err
+	}
+	abi, err := abi.JSON(bytes.NewReader(apiRouterABI))
+	if err != nil {
+		return nil, err
+	}
+	return abi.Pack("or", offsets, data)
+}
+
+// returns the calldata that has the router read the latest answer of a Chainlink price feed
+func oracleCall(feed common.Address) ([]byte, error) {
+	data, err := web3.LatestAnswerData()
+	if err != nil {
+		return nil, err
+	}
+	return arbitraryStaticCall(feed, data)
+}
+
+// returns a predicate that is true if the latest answer of a Chainlink price feed is greater than price (scaled)
+func oracleAbove(feed common.Address, price *big.Int) (predicate, error) {
+	call, err := oracleCall(feed)
+	if err != nil {
+		return nil, err
+	}
+	return gt(price, call)
+}
+
+// returns a predicate that is true if the latest answer of a Chainlink price feed is less than price (scaled)
+func oracleBelow(feed common.Address, price *big.Int) (predicate, error) {
+	call, err := oracleCall(feed)
+	if err != nil {
+		return nil, err
+	}
+	return lt(price, call)
+}
+
+// returns a predicate that is true if the current block is at (or after) this unix timestamp
+func timestampAt(chainId int64, timestamp int64) (predicate, error) {
+	msg, err := web3.TimestampCall(chainId)
+	if err != nil {
+		return nil, err
+	}
+	call, err := arbitraryStaticCall(*msg.To, msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	return gt(big.NewInt(timestamp-1), call)
+}
+
+// returns the predicate of your orders, or nil if you didn't include --activate-above, --activate-below or --activate-at
+func (client *Client) predicate() (predicate, error) {
+	activation, err := flag.GetActivation()
+	if err != nil || activation == nil {
+		return nil, err
+	}
+
+	var predicates []predicate
+
+	if activation.Above > 0 || activation.Below > 0 {
+		web3, err := web3.New(client.ChainId)
+		if err != nil {
+			return nil, err
+		}
+		decimals, err := web3.GetOracleDecimals(activation.Oracle)
+		if err != nil {
+			return nil, err
+		}
+		scale := func(price float64) *big.Int {
+			out, _ := new(big.Float).Mul(big.NewFloat(price), big.NewFloat(math.Pow10(decimals))).Int(nil)
+			return out
+		}
+		var prices []predicate
+		if activation.Above > 0 {
+			condition, err := oracleAbove(common.HexToAddress(activation.Oracle), scale(activation.Above))
+			if err != nil {
+				return nil, err
+			}
+			prices = append(prices, condition)
+		}
+		if activation.Below > 0 {
+			condition, err := oracleBelow(common.HexToAddress(activation.Oracle), scale(activation.Below))
+			if err != nil {
+				return nil, err
+			}
+			prices = append(prices, condition)
+		}
+		// above a low price and below a high price is a band, above a high price or below a low price is a breakout
+		if len(prices) == 2 && activation.Above >= activation.Below {
+			breakout, err := or(prices...)
+			if err != nil {
+				return nil, err
+			}
+			prices = []predicate{breakout}
+		}
+		predicates = append(predicates, prices...)
+	}
+
+	if !activation.At.IsZero() {
+		condition, err := timestampAt(client.ChainId, activation.At.Unix())
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, condition)
+	}
+
+	if len(predicates) == 1 {
+		return predicates[0], nil
+	}
+	return and(predicates...)
+}
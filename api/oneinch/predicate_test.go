@@ -0,0 +1,151 @@
+package oneinch
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// returns the 4-byte selector of a function signature
+func selector(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
+
+// unpacks the arguments of a call on the router, after checking its selector
+func unpack(t *testing.T, data []byte, signature, method string) []interface{} {
+	t.Helper()
+	if !bytes.HasPrefix(data, selector(signature)) {
+		t.Fatalf("expected a call to %s, got %x", signature, data[:4])
+	}
+	abi, err := abi.JSON(bytes.NewReader(apiRouterABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := abi.Methods[method].Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return args
+}
+
+func TestCombine(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		predicates []predicate
+		offsets    *big.Int
+		data       []byte
+		err        bool
+	}{
+		{"none", nil, big.NewInt(0), nil, false},
+		{"one", []predicate{{1, 2, 3}}, big.NewInt(3), []byte{1, 2, 3}, false},
+		{
+			"three",
+			[]predicate{{1, 2, 3}, {4, 5, 6, 7, 8}, {9, 10}},
+			new(big.Int).Or(big.NewInt(3|8<<32), new(big.Int).Lsh(big.NewInt(10), 64)),
+			[]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			false,
+		},
+		{"nine", []predicate{{1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}}, nil, nil, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			offsets, data, err := combine(test.predicates)
+			if test.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if offsets.Cmp(test.offsets) != 0 {
+				t.Errorf("expected offsets %x, got %x", test.offsets, offsets)
+			}
+			if !bytes.Equal(data, test.data) {
+				t.Errorf("expected data %x, got %x", test.data, data)
+			}
+		})
+	}
+}
+
+func TestOracle(t *testing.T) {
+	feed := common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419")
+	price := big.NewInt(3000_00000000)
+
+	for _, test := range []struct {
+		name      string
+		predicate func(common.Address, *big.Int) (predicate, error)
+		signature string
+		method    string
+	}{
+		{"above", oracleAbove, "gt(uint256,bytes)", "gt"},
+		{"below", oracleBelow, "lt(uint256,bytes)", "lt"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := test.predicate(feed, price)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := unpack(t, data, test.signature, test.method)
+			if value := args[0].(*big.Int); value.Cmp(price) != 0 {
+				t.Errorf("expected %v, got %v", price, value)
+			}
+			// the router calls the price feed's latestAnswer()
+			call := unpack(t, args[1].([]byte), "arbitraryStaticCall(address,bytes)", "arbitraryStaticCall")
+			if target := call[0].(common.Address); target != feed {
+				t.Errorf("expected a call to %s, got %s", feed.Hex(), target.Hex())
+			}
+			if data := call[1].([]byte); !bytes.Equal(data, selector("latestAnswer()")) {
+				t.Errorf("expected latestAnswer(), got %x", data)
+			}
+		})
+	}
+}
+
+func TestTimestampAt(t *testing.T) {
+	data, err := timestampAt(1, 1893456000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the block timestamp is greater than the timestamp minus one, in other words: at (or after) the timestamp
+	args := unpack(t, data, "gt(uint256,bytes)", "gt")
+	if value := args[0].(*big.Int); value.Int64() != 1893456000-1 {
+		t.Errorf("expected %d, got %v", 1893456000-1, value)
+	}
+	call := unpack(t, args[1].([]byte), "arbitraryStaticCall(address,bytes)", "arbitraryStaticCall")
+	if data := call[1].([]byte); !bytes.Equal(data, selector("getCurrentBlockTimestamp()")) {
+		t.Errorf("expected getCurrentBlockTimestamp(), got %x", data)
+	}
+}
+
+func TestAndOr(t *testing.T) {
+	first := predicate{1, 2, 3}
+	second := predicate{4, 5}
+	for _, test := range []struct {
+		name      string
+		predicate func(...predicate) (predicate, error)
+		signature string
+		method    string
+	}{
+		{"and", and, "and(uint256,bytes)", "and"},
+		{"or", or, "or(uint256,bytes)", "or"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := test.predicate(first, second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := unpack(t, data, test.signature, test.method)
+			if offsets := args[0].(*big.Int); offsets.Cmp(big.NewInt(3|5<<32)) != 0 {
+				t.Errorf("expected offsets %x, got %x", 3|5<<32, offsets)
+			}
+			if data := args[1].([]byte); !bytes.Equal(data, []byte{1, 2, 3, 4, 5}) {
+				t.Errorf("expected the predicates one after the other, got %x", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package oneinch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// GetQuote returns how much of dst you would receive if you were to swap amount of src (both amounts are scaled)
+func (client *Client) GetQuote(src, dst string, amount *big.Int) (*big.Int, error) {
+	body, err := client.get(fmt.Sprintf("/swap/v6.0/%d/quote?src=%s&dst=%s&amount=%s", client.ChainId, src, dst, amount.String()))
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		DstAmount string `json:"dstAmount"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	i, ok := new(big.Int).SetString(response.DstAmount, 10)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert %s to big.Int", response.DstAmount)
+	}
+	return i, nil
+}
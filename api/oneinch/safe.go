@@ -0,0 +1,212 @@
+package oneinch
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/svanas/ladder/api/web3"
+	consts "github.com/svanas/ladder/constants"
+)
+
+// signs the order on behalf of a Safe (EIP-1271). this only works if the Safe needs one signature, orders of a Safe
+// that needs more than one signature will need to be planned, signed by the owners, and then submitted.
+func (client *Client) signSafe(order *Order, privateKey *ecdsa.PrivateKey) error {
+	maker := client.maker.Hex()
+
+	hash := common.HexToHash(order.OrderHash)
+	signature, err := web3.SignSafeMessage(privateKey, client.ChainId, maker, hash)
+	if err != nil {
+		return err
+	}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return err
+	}
+
+	contract, err := web3.IsContract(maker)
+	if err != nil {
+		return err
+	}
+	if !contract {
+		return fmt.Errorf("--%s %s is not a smart-contract wallet, and not the owner of your private key", consts.FLAG_MAKER, maker)
+	}
+
+	owners, err := web3.GetOwners(maker)
+	if err != nil {
+		return err
+	}
+	signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+	if !isOwner(owners, signer) {
+		return fmt.Errorf("%s is not an owner of %s", signer.Hex(), maker)
+	}
+
+	threshold, err := web3.GetThreshold(maker)
+	if err != nil {
+		return err
+	}
+	if threshold > 1 {
+		return fmt.Errorf("%s needs %d signatures, please use the plan, sign and submit commands", maker, threshold)
+	}
+
+	// have the Safe check the signature before we post the order
+	valid, err := web3.IsValidSignature(maker, hash, signature)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("%s did not accept the signature of order %s", maker, order.OrderHash)
+	}
+
+	order.Signature = hexutil.Encode(signature)
+	return nil
+}
+
+func isOwner(owners []common.Address, address common.Address) bool {
+	for _, owner := range owners {
+		if owner == address {
+			return true
+		}
+	}
+	return false
+}
+
+// NewPlan returns an empty plan for the maker. if the maker is a Safe, the plan includes its owners and threshold.
+func (client *Client) NewPlan() (*Plan, error) {
+	plan := &Plan{ChainId: client.ChainId}
+
+	maker, err := client.publicAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := web3.IsContract(maker.Hex())
+	if err != nil || !contract {
+		return plan, err
+	}
+
+	owners, err := web3.GetOwners(maker.Hex())
+	if err != nil {
+		return nil, err
+	}
+	for _, owner := range owners {
+		plan.Owners = append(plan.Owners, owner.Hex())
+	}
+
+	if plan.Threshold, err = web3.GetThreshold(maker.Hex()); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// returns true if the maker is a Safe
+func (plan *Plan) safe() bool {
+	return plan.Threshold > 0
+}
+
+func (plan *Plan) isOwner(address common.Address) bool {
+	for _, owner := range plan.Owners {
+		if strings.EqualFold(owner, address.Hex()) {
+			return true
+		}
+	}
+	return false
+}
+
+// checks the SafeMessage against the order hash, and every owner signature against the owners of the Safe
+func (plan *Plan) verifySafe(order *PlannedOrder) error {
+	message := web3.SafeMessage(plan.ChainId, order.Data.Maker, common.HexToHash(order.OrderHash))
+	hash, err := hashTypedData(message)
+	if err != nil {
+		return err
+	}
+	if order.SafeMessage == nil {
+		return fmt.Errorf("order %s does not have a Safe message", order.OrderHash)
+	}
+	if typedDataHash, err := hashTypedData(*order.SafeMessage); err != nil || typedDataHash != hash {
+		return fmt.Errorf("order %s does not match its Safe message", order.OrderHash)
+	}
+
+	signatures := make(map[common.Address][]byte)
+	for owner, signature := range order.Signatures {
+		signer, err := recoverSigner(hash, signature)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(signer.Hex(), owner) || !plan.isOwner(signer) {
+			return fmt.Errorf("order %s has an invalid signature by %s", order.OrderHash, owner)
+		}
+		signatures[signer], _ = hexutil.Decode(signature)
+	}
+
+	if order.Signed() && order.Signature != hexutil.Encode(web3.SafeSignature(signatures)) {
+		return fmt.Errorf("order %s does not match its owner signatures", order.OrderHash)
+	}
+
+	return nil
+}
+
+// adds the signature of one owner to every order. once enough owners have signed, the order gets its EIP-1271 signature.
+func (plan *Plan) signSafe(privateKey *ecdsa.PrivateKey) error {
+	signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+	if !plan.isOwner(signer) {
+		return fmt.Errorf("%s is not an owner of this Safe", signer.Hex())
+	}
+
+	for i := range plan.Orders {
+		order := &plan.Orders[i]
+
+		signature, err := web3.SignSafeMessage(privateKey, plan.ChainId, order.Data.Maker, common.HexToHash(order.OrderHash))
+		if err != nil {
+			return err
+		}
+		if order.Signatures == nil {
+			order.Signatures = make(map[string]string)
+		}
+		order.Signatures[signer.Hex()] = hexutil.Encode(signature)
+
+		if len(order.Signatures) >= plan.Threshold {
+			signatures := make(map[common.Address][]byte)
+			for owner, signature := range order.Signatures {
+				if signatures[common.HexToAddress(owner)], err = hexutil.Decode(signature); err != nil {
+					return err
+				}
+			}
+			order.Signature = hexutil.Encode(web3.SafeSignature(signatures))
+		}
+	}
+
+	return nil
+}
+
+// has the Safe check every signature, in case its owners or threshold have changed since the plan was built
+func (plan *Plan) validateSafe() error {
+	web3, err := web3.New(plan.ChainId)
+	if err != nil {
+		return err
+	}
+	for _, order := range plan.Orders {
+		signature, err := hexutil.Decode(order.Signature)
+		if err != nil {
+			return err
+		}
+		valid, err := web3.IsValidSignature(order.Data.Maker, common.HexToHash(order.OrderHash), signature)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return fmt.Errorf("%s did not accept the signature of order %s", order.Data.Maker, order.OrderHash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package paper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/flag"
+	"github.com/svanas/ladder/precision"
+	"github.com/svanas/ladder/uuid"
+)
+
+// ErrPostOnly is returned when a post-only order would have been filled immediately
+var ErrPostOnly = errors.New("post-only order would have been filled immediately")
+
+type State struct {
+	Balances map[string]float64 `json:"balances"` // total balance per asset, including the amount reserved in open orders
+	Orders   []Order            `json:"orders"`   // open orders
+	Fills    []Fill             `json:"fills"`    // filled orders
+	Prices   map[string]float64 `json:"prices"`   // last price per market
+}
+
+type Client struct {
+	path  string
+	state State
+}
+
+func split(market string) (string, string) { // --> (asset, quote)
+	symbols := strings.Split(market, "-")
+	if len(symbols) > 1 {
+		return symbols[0], symbols[1]
+	}
+	return market, ""
+}
+
+func (self *Client) load() error {
+	self.state = State{
+		Balances: make(map[string]float64),
+		Prices:   make(map[string]float64),
+	}
+	data, err := os.ReadFile(self.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if err := json.Unmarshal(data, &self.state); err != nil {
+		return fmt.Errorf("cannot parse %s: %v", self.path, err)
+	}
+	if self.state.Balances == nil {
+		self.state.Balances = make(map[string]float64)
+	}
+	if self.state.Prices == nil {
+		self.state.Prices = make(map[string]float64)
+	}
+	// drop the orders that have expired
+	var open []Order
+	for _, order := range self.state.Orders {
+		if !order.expired() {
+			open = append(open, order)
+		}
+	}
+	self.state.Orders = open
+	return nil
+}
+
+func (self *Client) save() error {
+	data, err := json.MarshalIndent(&self.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(self.path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(self.path, data, 0600)
+}
+
+func (self *Client) Path() string {
+	return self.path
+}
+
+func (self *Client) Balances() map[string]float64 {
+	return self.state.Balances
+}
+
+// returns the amount of an asset that is locked in open orders
+func (self *Client) Reserved(asset string) float64 {
+	var out float64
+	for _, order := range self.state.Orders {
+		if reserved, amount := order.reserved(); strings.EqualFold(reserved, asset) {
+			out += amount
+		}
+	}
+	return out
+}
+
+// Deposit adds amount to your balance. a negative amount withdraws, but never the funds reserved in your open orders.
+func (self *Client) Deposit(asset string, amount float64) error {
+	asset = strings.ToUpper(asset)
+	if free := self.state.Balances[asset] - self.Reserved(asset); precision.Round(free+amount, sizePrecision) < 0 {
+		return fmt.Errorf("insufficient %s balance. available: %v, required: %v", asset, free, -amount)
+	}
+	self.state.Balances[asset] += amount
+	return self.save()
+}
+
+func (self *Client) GetPrecision() (int, int) { // --> (price, size)
+	return pricePrecision, sizePrecision
+}
+
+// returns the last known price, or an error if the market hasn't ticked yet
+func (self *Client) GetTicker(market string) (float64, error) {
+	if price, ok := self.state.Prices[market]; ok {
+		return price, nil
+	}
+	return 0, fmt.Errorf("%s hasn't ticked yet, please run the paper tick command first", market)
+}
+
+func (self *Client) GetOpenOrders(market string, side consts.OrderSide) []Order {
+	var out []Order
+	for _, order := range self.state.Orders {
+		if order.Market == market && order.Side == side {
+			out = append(out, order)
+		}
+	}
+	return out
+}
+
+func (self *Client) GetFills(market string) []Fill {
+	var out []Fill
+	for _, fill := range self.state.Fills {
+		if market == "" || fill.Market == market {
+			out = append(out, fill)
+		}
+	}
+	return out
+}
+
+func (self *Client) newOrder(market string, side consts.OrderSide, size, price float64, expiry time.Time, postOnly bool) (*Order, error) {
+	if size <= 0 || price <= 0 {
+		return nil, fmt.Errorf("invalid order: size %v, price %v", size, price)
+	}
+	order := Order{
+		Id:      uuid.New().String(),
+		Market:  market,
+		Side:    side,
+		Size:    size,
+		Price:   price,
+		Created: time.Now().Unix(),
+	}
+	if !expiry.IsZero() {
+		order.Expiry = expiry.Unix()
+	}
+	// a post-only order is rejected if it would have been filled immediately
+	if postOnly {
+		if last, ok := self.state.Prices[market]; ok && order.crossed(last) {
+			return nil, ErrPostOnly
+		}
+	}
+	asset, amount := order.reserved()
+	if asset == "" {
+		return nil, fmt.Errorf("market %s does not exist", market)
+	}
+	free := self.state.Balances[asset] - self.Reserved(asset)
+	if precision.Round(free-amount, sizePrecision) < 0 {
+		return nil, fmt.Errorf("insufficient %s balance. available: %v, required: %v", asset, free, amount)
+	}
+	return &order, nil
+}
+
+// CreateOrder places a limit order. if expiry is zero, the order is good-til-cancelled.
+func (self *Client) CreateOrder(market string, side consts.OrderSide, size, price float64, expiry time.Time, postOnly bool) (string, error) { // --> (orderId, error)
+	order, err := self.newOrder(market, side, size, price, expiry, postOnly)
+	if err != nil {
+		return "", err
+	}
+	self.state.Orders = append(self.state.Orders, *order)
+	return order.Id, self.save()
+}
+
+// ValidateOrder checks if an order would be accepted, but does not place it
+func (self *Client) ValidateOrder(market string, side consts.OrderSide, size, price float64, expiry time.Time, postOnly bool) error {
+	_, err := self.newOrder(market, side, size, price, expiry, postOnly)
+	return err
+}
+
+func (self *Client) CancelOrder(orderId string) error {
+	for i, order := range self.state.Orders {
+		if order.Id == orderId {
+			self.state.Orders = append(self.state.Orders[:i], self.state.Orders[i+1:]...)
+			return self.save()
+		}
+	}
+	return fmt.Errorf("order %s does not exist", orderId)
+}
+
+// Tick updates the market price and fills every open order that has been crossed
+func (self *Client) Tick(market string, price float64) ([]Fill, error) {
+	if price <= 0 {
+		return nil, fmt.Errorf("invalid price %v", price)
+	}
+	self.state.Prices[market] = price
+
+	var (
+		fills []Fill
+		open  []Order
+	)
+	for _, order := range self.state.Orders {
+		if order.Market != market || !order.crossed(price) {
+			open = append(open, order)
+			continue
+		}
+		asset, quote := split(order.Market)
+		switch order.Side {
+		case consts.BUY:
+			self.state.Balances[quote] -= order.Size * order.Price
+			self.state.Balances[asset] += order.Size
+		case consts.SELL:
+			self.state.Balances[asset] -= order.Size
+			self.state.Balances[quote] += order.Size * order.Price
+		}
+		fills = append(fills, Fill{Order: order, Filled: time.Now().Unix()})
+	}
+	self.state.Orders = open
+	self.state.Fills = append(self.state.Fills, fills...)
+
+	return fills, self.save()
+}
+
+func New() (*Client, error) {
+	path := flag.PaperState()
+	if path == "" {
+		var err error
+		if path, err = defaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	client := &Client{path: path}
+	if err := client.load(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,184 @@
+package paper
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/precision"
+)
+
+// returns a client with a fresh state file, 1 BTC and 10000 USDT, and BTC-USDT at 60000
+func newClient(t *testing.T) *Client {
+	client := &Client{path: filepath.Join(t.TempDir(), stateFileName)}
+	if err := client.load(); err != nil {
+		t.Fatal(err)
+	}
+	client.state.Balances["BTC"] = 1
+	client.state.Balances["USDT"] = 10000
+	client.state.Prices["BTC-USDT"] = 60000
+	return client
+}
+
+func TestCreateOrder(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		side     consts.OrderSide
+		size     float64
+		price    float64
+		postOnly bool
+		err      string
+	}{
+		{"buy", consts.BUY, 0.1, 59000, false, ""},
+		{"sell", consts.SELL, 0.5, 61000, false, ""},
+		{"buy everything", consts.BUY, 0.2, 50000, false, ""},
+		{"buy too much", consts.BUY, 0.2, 50001, false, "insufficient USDT balance"},
+		{"sell too much", consts.SELL, 1.1, 61000, false, "insufficient BTC balance"},
+		{"zero size", consts.SELL, 0, 61000, false, "invalid order"},
+		{"zero price", consts.BUY, 0.1, 0, false, "invalid order"},
+		{"crossed buy", consts.BUY, 0.1, 60000, false, ""},
+		{"crossed post-only buy", consts.BUY, 0.1, 60000, true, "post-only"},
+		{"crossed post-only sell", consts.SELL, 0.1, 59000, true, "post-only"},
+		{"post-only sell", consts.SELL, 0.1, 60001, true, ""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client := newClient(t)
+			_, err := client.CreateOrder("BTC-USDT", test.side, test.size, test.price, time.Time{}, test.postOnly)
+			if test.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Fatalf("expected an error that contains %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestReserved(t *testing.T) {
+	client := newClient(t)
+	if _, err := client.CreateOrder("BTC-USDT", consts.BUY, 0.1, 50000, time.Time{}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateOrder("BTC-USDT", consts.SELL, 0.25, 70000, time.Time{}, false); err != nil {
+		t.Fatal(err)
+	}
+	if reserved := client.Reserved("USDT"); reserved != 5000 {
+		t.Errorf("expected 5000 USDT reserved, got %v", reserved)
+	}
+	if reserved := client.Reserved("btc"); reserved != 0.25 {
+		t.Errorf("expected 0.25 BTC reserved, got %v", reserved)
+	}
+	// the second buy order only has the funds that aren't reserved by the first one
+	if _, err := client.CreateOrder("BTC-USDT", consts.BUY, 0.1, 50001, time.Time{}, false); err == nil {
+		t.Error("expected an error, because 5000 USDT is reserved")
+	}
+}
+
+func TestDeposit(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		asset   string
+		amount  float64
+		balance float64
+		err     string
+	}{
+		{"deposit", "USDT", 1000, 11000, ""},
+		{"deposit a new asset", "eth", 2, 2, ""},
+		{"withdraw what is free", "USDT", -5000, 5000, ""},
+		{"withdraw what is reserved", "USDT", -5001, 10000, "insufficient USDT balance"},
+		{"withdraw more than the balance", "BTC", -2, 1, "insufficient BTC balance"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client := newClient(t)
+			// reserve 5000 USDT
+			if _, err := client.CreateOrder("BTC-USDT", consts.BUY, 0.1, 50000, time.Time{}, false); err != nil {
+				t.Fatal(err)
+			}
+			err := client.Deposit(test.asset, test.amount)
+			if test.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Fatalf("expected an error that contains %q, got %v", test.err, err)
+			}
+			if balance := client.Balances()[strings.ToUpper(test.asset)]; balance != test.balance {
+				t.Errorf("expected a balance of %v, got %v", test.balance, balance)
+			}
+		})
+	}
+}
+
+func TestGetTicker(t *testing.T) {
+	client := newClient(t)
+	if _, err := client.GetTicker("ETH-USDT"); err == nil {
+		t.Error("expected an error, because ETH-USDT hasn't ticked yet")
+	}
+	price, err := client.GetTicker("BTC-USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price != 60000 {
+		t.Errorf("expected 60000, got %v", price)
+	}
+}
+
+func TestTick(t *testing.T) {
+	client := newClient(t)
+	buy, err := client.CreateOrder("BTC-USDT", consts.BUY, 0.1, 59000, time.Time{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sell, err := client.CreateOrder("BTC-USDT", consts.SELL, 0.5, 61000, time.Time{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		price  float64
+		filled []string
+		btc    float64
+		usdt   float64
+	}{
+		{60500, nil, 1, 10000},
+		{59000, []string{buy}, 1.1, 4100},
+		{58000, nil, 1.1, 4100},
+		{62000, []string{sell}, 0.6, 34600},
+	} {
+		fills, err := client.Tick("BTC-USDT", test.price)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(fills) != len(test.filled) {
+			t.Fatalf("%v: expected %d fill(s), got %d", test.price, len(test.filled), len(fills))
+		}
+		for i, fill := range fills {
+			if fill.Id != test.filled[i] {
+				t.Errorf("%v: expected order %s to fill, got %s", test.price, test.filled[i], fill.Id)
+			}
+		}
+		if balance := client.Balances()["BTC"]; precision.Round(balance, sizePrecision) != test.btc {
+			t.Errorf("%v: expected %v BTC, got %v", test.price, test.btc, balance)
+		}
+		if balance := client.Balances()["USDT"]; precision.Round(balance, sizePrecision) != test.usdt {
+			t.Errorf("%v: expected %v USDT, got %v", test.price, test.usdt, balance)
+		}
+	}
+
+	if len(client.GetOpenOrders("BTC-USDT", consts.BUY))+len(client.GetOpenOrders("BTC-USDT", consts.SELL)) != 0 {
+		t.Error("expected every order to be filled")
+	}
+	if len(client.GetFills("BTC-USDT")) != 2 {
+		t.Errorf("expected 2 fills, got %d", len(client.GetFills("BTC-USDT")))
+	}
+
+	// the state survives a reload
+	reloaded := &Client{path: client.path}
+	if err := reloaded.load(); err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.GetFills("")) != 2 || reloaded.Balances()["USDT"] != 34600 {
+		t.Errorf("expected the state to be saved, got %+v", reloaded.state)
+	}
+}
@@ -0,0 +1,21 @@
+package paper
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const (
+	pricePrecision = 8
+	sizePrecision  = 8
+	stateFileName  = "paper.json"
+)
+
+// returns the default location of the paper trading state file, for example ~/.config/ladder/paper.json
+func defaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ladder", stateFileName), nil
+}
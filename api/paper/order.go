@@ -0,0 +1,47 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package paper
+
+import (
+	"time"
+
+	consts "github.com/svanas/ladder/constants"
+)
+
+type Order struct {
+	Id      string           `json:"id"`
+	Market  string           `json:"market"` // for example: BTC-USDT
+	Side    consts.OrderSide `json:"side"`
+	Size    float64          `json:"size"`
+	Price   float64          `json:"price"`
+	Created int64            `json:"created"`          // unix timestamp
+	Expiry  int64            `json:"expiry,omitempty"` // unix timestamp, or zero if this order is good-til-cancelled
+}
+
+func (self *Order) expired() bool {
+	return self.Expiry > 0 && time.Now().Unix() >= self.Expiry
+}
+
+type Fill struct {
+	Order
+	Filled int64 `json:"filled"` // unix timestamp
+}
+
+// returns the asset that will be reserved (locked) while this order is open
+func (self *Order) reserved() (string, float64) {
+	asset, quote := split(self.Market)
+	if self.Side == consts.BUY {
+		return quote, self.Size * self.Price
+	}
+	return asset, self.Size
+}
+
+// returns true if this order will be filled at the given market price
+func (self *Order) crossed(price float64) bool {
+	switch self.Side {
+	case consts.BUY:
+		return price <= self.Price
+	case consts.SELL:
+		return price >= self.Price
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package paper
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ReadPrices reads a price feed from a CSV file. the price is expected in the last column of every row,
+// for example "price" or "timestamp,price". rows that do not end with a number (for example: a header) are skipped.
+func ReadPrices(path string) ([]float64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var out []float64
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) == 0 {
+			continue
+		}
+		price, err := strconv.ParseFloat(strings.TrimSpace(record[len(record)-1]), 64)
+		if err != nil || price <= 0 {
+			continue
+		}
+		out = append(out, price)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package web3
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Chain is an entry in our chain registry
+type Chain struct {
+	Id                int64    `json:"chainId"`
+	Name              string   `json:"name"`
+	NativeCoin        string   `json:"nativeCoin"`               // the symbol of the coin that is used to pay for gas
+	WrappedNativeCoin string   `json:"wrappedNativeCoin"`        // the address of the ERC-20 token that wraps the native coin
+	CoinGecko         string   `json:"coingecko,omitempty"`      // the CoinGecko asset platform, looked up by chain id if empty
+	RPC               []string `json:"rpc,omitempty"`            // the public RPC endpoints we use by default
+	Router            string   `json:"router,omitempty"`         // the 1inch aggregation router, if it isn't the usual one
+	UniswapFactory    string   `json:"uniswapFactory,omitempty"` // the Uniswap v3 factory, if any
+	Multicall3        string   `json:"multicall3,omitempty"`     // the Multicall3 contract, if it isn't the canonical one
+	DutchAuction      string   `json:"dutchAuction,omitempty"`   // the 1inch DutchAuctionCalculator, if any
+}
+
+//go:embed chains.json
+var defaultChains []byte
+
+var (
+	chainsOnce sync.Once
+	chains     map[int64]*Chain
+	chainsErr  error
+)
+
+// returns the location of your own chain registry, for example ~/.config/ladder/chains.json
+func chainsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ladder", "chains.json"), nil
+}
+
+// merges other into chain. the fields that are set in other take precedence.
+func (chain *Chain) merge(other Chain) {
+	if other.Name != "" {
+		chain.Name = other.Name
+	}
+	if other.NativeCoin != "" {
+		chain.NativeCoin = other.NativeCoin
+	}
+	if other.WrappedNativeCoin != "" {
+		chain.WrappedNativeCoin = other.WrappedNativeCoin
+	}
+	if other.CoinGecko != "" {
+		chain.CoinGecko = other.CoinGecko
+	}
+	if len(other.RPC) > 0 {
+		chain.RPC = other.RPC
+	}
+	if other.Router != "" {
+		chain.Router = other.Router
+	}
+	if other.UniswapFactory != "" {
+		chain.UniswapFactory = other.UniswapFactory
+	}
+	if other.Multicall3 != "" {
+		chain.Multicall3 = other.Multicall3
+	}
+	if other.DutchAuction != "" {
+		chain.DutchAuction = other.DutchAuction
+	}
+}
+
+// returns our default chain registry, merged with your own chain registry (if any)
+func loadChains() (map[int64]*Chain, error) {
+	var defaults []Chain
+	if err := json.Unmarshal(defaultChains, &defaults); err != nil {
+		return nil, fmt.Errorf("cannot parse chains.json: %v", err)
+	}
+	out := make(map[int64]*Chain)
+	for i := range defaults {
+		out[defaults[i].Id] = &defaults[i]
+	}
+
+	path, err := chainsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return out, nil
+		}
+		return nil, err
+	}
+	var yours []Chain
+	if err := json.Unmarshal(data, &yours); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %v", path, err)
+	}
+	for _, chain := range yours {
+		if existing, ok := out[chain.Id]; ok {
+			existing.merge(chain)
+		} else {
+			out[chain.Id] = &chain
+		}
+	}
+
+	return out, nil
+}
+
+// GetChain returns this chain's entry in our chain registry
+func GetChain(chainId int64) (*Chain, error) {
+	chainsOnce.Do(func() {
+		chains, chainsErr = loadChains()
+	})
+	if chainsErr != nil {
+		return nil, chainsErr
+	}
+	if chain, ok := chains[chainId]; ok {
+		return chain, nil
+	}
+	return nil, fmt.Errorf("chain %d is not supported at this time", chainId)
+}
@@ -0,0 +1,82 @@
+package web3
+
+import (
+	"bytes"
+	_ "embed"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed chainlink.abi.json
+var chainlink []byte
+
+// LatestAnswerData returns the calldata that reads the latest answer of a Chainlink price feed
+func LatestAnswerData() ([]byte, error) {
+	parsed, err := abi.JSON(bytes.NewReader(chainlink))
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Methods["latestAnswer"].ID, nil
+}
+
+// GetOracleDecimals returns the number of decimals of a Chainlink price feed
+func (client *Client) GetOracleDecimals(feed string) (int, error) {
+	parsed, err := abi.JSON(bytes.NewReader(chainlink))
+	if err != nil {
+		return 0, err
+	}
+
+	// query the chain
+	contract := common.HexToAddress(feed)
+	response, err := client.Call(ethereum.CallMsg{
+		To:   &contract,
+		Data: parsed.Methods["decimals"].ID,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// unpack the result
+	var decimals uint8
+	if err := parsed.UnpackIntoInterface(&decimals, "decimals", response); err != nil {
+		return 0, err
+	}
+
+	return int(decimals), nil
+}
+
+// GetOraclePrice returns the latest answer of a Chainlink price feed
+func (client *Client) GetOraclePrice(feed string) (float64, error) {
+	decimals, err := client.GetOracleDecimals(feed)
+	if err != nil {
+		return 0, err
+	}
+
+	parsed, err := abi.JSON(bytes.NewReader(chainlink))
+	if err != nil {
+		return 0, err
+	}
+
+	// query the chain
+	contract := common.HexToAddress(feed)
+	response, err := client.Call(ethereum.CallMsg{
+		To:   &contract,
+		Data: parsed.Methods["latestAnswer"].ID,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// unpack the result
+	var answer *big.Int
+	if err := parsed.UnpackIntoInterface(&answer, "latestAnswer", response); err != nil {
+		return 0, err
+	}
+
+	out, _ := new(big.Float).Quo(new(big.Float).SetInt(answer), big.NewFloat(math.Pow10(decimals))).Float64()
+	return out, nil
+}
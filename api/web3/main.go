@@ -6,74 +6,103 @@ import (
 	_ "embed"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	geth "github.com/ethereum/go-ethereum/ethclient"
+	consts "github.com/svanas/ladder/constants"
 )
 
 type Client struct {
-	client *geth.Client
+	chainId   int64
+	endpoints []string
+	endpoint  int            // the endpoint whose turn it is
+	conns     []*geth.Client // the connection to every endpoint, or nil if we haven't connected (yet)
+	mutex     sync.Mutex
+	calls     map[string][]byte // the result of every eth_call (at the latest block), until we send a transaction
 }
 
 //go:embed erc20.abi.json
 var erc20 []byte
 
-const (
-	Ethereum    int64 = 1
-	Optimism    int64 = 10
-	BnbChain    int64 = 56
-	GnosisChain int64 = 100
-	Polygon     int64 = 137
-	Sonic       int64 = 146
-	Base        int64 = 8453
-	Arbitrum    int64 = 42161
-	Avalanche   int64 = 43114
-)
+// returns the public RPC endpoints we use by default, unless you configured your own
+func defaultEndpoints(chainId int64) ([]string, error) {
+	chain, err := GetChain(chainId)
+	if err != nil || len(chain.RPC) == 0 {
+		return nil, fmt.Errorf("chain %d is not supported at this time, please include --%s with your command line", chainId, consts.FLAG_RPC)
+	}
+	return chain.RPC, nil
+}
 
-func Endpoint(chainId int64) (string, error) {
-	switch chainId {
-	case Ethereum:
-		return "https://1rpc.io/eth", nil
-	case Optimism:
-		return "https://1rpc.io/op", nil
-	case BnbChain:
-		return "https://1rpc.io/bnb", nil
-	case GnosisChain:
-		return "https://1rpc.io/gnosis", nil
-	case Polygon:
-		return "https://1rpc.io/matic", nil
-	case Sonic:
-		return "https://1rpc.io/sonic", nil
-	case Base:
-		return "https://1rpc.io/base", nil
-	case Arbitrum:
-		return "https://1rpc.io/arb", nil
-	case Avalanche:
-		return "https://1rpc.io/avax/c", nil
-	}
-	return "", fmt.Errorf("chain %d is not supported at this time", chainId)
+// returns the symbol of the coin that is used to pay for gas
+func NativeCoin(chainId int64) string {
+	if chain, err := GetChain(chainId); err == nil && chain.NativeCoin != "" {
+		return chain.NativeCoin
+	}
+	return "ETH"
+}
+
+// returns the address of the ERC-20 token that wraps the native coin
+func WrappedNativeCoin(chainId int64) (string, error) {
+	chain, err := GetChain(chainId)
+	if err != nil {
+		return "", err
+	}
+	if chain.WrappedNativeCoin == "" {
+		return "", fmt.Errorf("%s does not have a wrapped %s", chain.Name, chain.NativeCoin)
+	}
+	return chain.WrappedNativeCoin, nil
 }
 
 func Checksum(address string) string {
 	return common.HexToAddress(address).Hex()
 }
 
-func New(chainId int64) (*Client, error) {
-	url, err := Endpoint(chainId)
-	if err != nil {
-		return nil, err
+// returns the key of an eth_call in our cache
+func callKey(msg ethereum.CallMsg) string {
+	return fmt.Sprintf("%s:%s:%x", msg.From.Hex(), msg.To.Hex(), msg.Data)
+}
+
+// Call executes a contract call. the result is cached (until we send a transaction), unless you ask for a block number.
+func (client *Client) Call(msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if blockNumber != nil || msg.To == nil {
+		return retry(client, func(conn *geth.Client) ([]byte, error) {
+			return conn.CallContract(context.Background(), msg, blockNumber)
+		})
+	}
+	key := callKey(msg)
+	client.mutex.Lock()
+	cached, ok := client.calls[key]
+	client.mutex.Unlock()
+	if ok {
+		return cached, nil
 	}
-	client, err := geth.Dial(url)
+	response, err := retry(client, func(conn *geth.Client) ([]byte, error) {
+		return conn.CallContract(context.Background(), msg, nil)
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &Client{client}, nil
+	client.cache(key, response)
+	return response, nil
 }
 
-func (client *Client) Call(msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	return client.client.CallContract(context.Background(), msg, blockNumber)
+func (client *Client) cache(key string, response []byte) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.calls == nil {
+		client.calls = make(map[string][]byte)
+	}
+	client.calls[key] = response
+}
+
+// forgets every cached eth_call, because a transaction might have changed the outcome
+func (client *Client) invalidate() {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.calls = nil
 }
 
 func (client *Client) GetSymbol(contract string) (string, error) {
@@ -141,6 +170,55 @@ func (client *Client) getAllowance(contract, owner, spender common.Address) (*bi
 	return allowance, nil
 }
 
+// GetNativeBalance returns the balance of the coin that is used to pay for gas (in wei)
+func (client *Client) GetNativeBalance(owner string) (*big.Int, error) {
+	return retry(client, func(conn *geth.Client) (*big.Int, error) {
+		return conn.BalanceAt(context.Background(), common.HexToAddress(owner), nil)
+	})
+}
+
+func (client *Client) GetBalance(contract, owner string) (*big.Int, error) {
+	return client.getBalance(common.HexToAddress(contract), common.HexToAddress(owner))
+}
+
+func (client *Client) getBalance(contract, owner common.Address) (*big.Int, error) {
+	parsed, err := abi.JSON(bytes.NewReader(erc20))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parsed.Pack("balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	// query the chain
+	response, err := client.Call(ethereum.CallMsg{
+		To:   &contract,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// unpack the result
+	var balance *big.Int
+	if err := parsed.UnpackIntoInterface(&balance, "balanceOf", response); err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// returns the calldata of an ERC-20 approve
+func ApproveData(spender string, amount *big.Int) ([]byte, error) {
+	parsed, err := abi.JSON(bytes.NewReader(erc20))
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Pack("approve", common.HexToAddress(spender), amount)
+}
+
 func (client *Client) GetDecimals(contract string) (int, error) {
 	return client.getDecimals(common.HexToAddress(contract))
 }
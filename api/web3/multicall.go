@@ -0,0 +1,134 @@
+package web3
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	geth "github.com/ethereum/go-ethereum/ethclient"
+)
+
+//go:embed multicall3.abi.json
+var multicall3 []byte
+
+// the canonical Multicall3 contract, deployed at the same address on (almost) every chain
+const Multicall3 = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+// returns the address of the Multicall3 contract on this chain
+func multicall3Address(chainId int64) string {
+	if chain, err := GetChain(chainId); err == nil && chain.Multicall3 != "" {
+		return chain.Multicall3
+	}
+	return Multicall3
+}
+
+// TimestampCall returns the call that reads the timestamp of the current block
+func TimestampCall(chainId int64) (*ethereum.CallMsg, error) {
+	parsed, err := abi.JSON(bytes.NewReader(multicall3))
+	if err != nil {
+		return nil, err
+	}
+	contract := common.HexToAddress(multicall3Address(chainId))
+	return &ethereum.CallMsg{To: &contract, Data: parsed.Methods["getCurrentBlockTimestamp"].ID}, nil
+}
+
+// Prefetch executes many contract calls in one round-trip (through Multicall3) and caches their results. this is
+// best-effort: the calls that fail (or every call, on a chain without Multicall3) fall back to one eth_call each.
+func (client *Client) Prefetch(calls []ethereum.CallMsg) {
+	type call3 struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+
+	// skip the calls we have cached already
+	var (
+		keys []string
+		args []call3
+	)
+	client.mutex.Lock()
+	for _, call := range calls {
+		key := callKey(call)
+		if _, ok := client.calls[key]; !ok && call.To != nil && call.From == (common.Address{}) {
+			keys = append(keys, key)
+			args = append(args, call3{*call.To, true, call.Data})
+		}
+	}
+	client.mutex.Unlock()
+	if len(args) == 0 {
+		return
+	}
+
+	parsed, err := abi.JSON(bytes.NewReader(multicall3))
+	if err != nil {
+		return
+	}
+	data, err := parsed.Pack("aggregate3", args)
+	if err != nil {
+		return
+	}
+
+	contract := common.HexToAddress(multicall3Address(client.chainId))
+	response, err := retry(client, func(conn *geth.Client) ([]byte, error) {
+		return conn.CallContract(context.Background(), ethereum.CallMsg{To: &contract, Data: data}, nil)
+	})
+	if err != nil {
+		return
+	}
+
+	out, err := parsed.Unpack("aggregate3", response)
+	if err != nil || len(out) == 0 {
+		return
+	}
+	results := *abi.ConvertType(out[0], new([]struct {
+		Success    bool
+		ReturnData []byte
+	})).(*[]struct {
+		Success    bool
+		ReturnData []byte
+	})
+	if len(results) != len(keys) {
+		return
+	}
+
+	for i, result := range results {
+		if result.Success && len(result.ReturnData) > 0 {
+			client.cache(keys[i], result.ReturnData)
+		}
+	}
+}
+
+// TokenCalls returns the calls that read the decimals and the symbol of a token. if owner isn't empty, this includes
+// the owner's balance, and if spender isn't empty, the spender's allowance.
+func TokenCalls(token, owner, spender string) ([]ethereum.CallMsg, error) {
+	parsed, err := abi.JSON(bytes.NewReader(erc20))
+	if err != nil {
+		return nil, err
+	}
+
+	contract := common.HexToAddress(token)
+	calls := []ethereum.CallMsg{
+		{To: &contract, Data: parsed.Methods["decimals"].ID},
+		{To: &contract, Data: parsed.Methods["symbol"].ID},
+	}
+
+	if owner != "" {
+		data, err := parsed.Pack("balanceOf", common.HexToAddress(owner))
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, ethereum.CallMsg{To: &contract, Data: data})
+		if spender != "" {
+			data, err := parsed.Pack("allowance", common.HexToAddress(owner), common.HexToAddress(spender))
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, ethereum.CallMsg{To: &contract, Data: data})
+		}
+	}
+
+	return calls, nil
+}
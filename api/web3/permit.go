@@ -0,0 +1,281 @@
+package web3
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+//go:embed permit2.abi.json
+var permit2 []byte
+
+// the canonical Uniswap Permit2 contract, deployed at the same address on every chain
+const Permit2 = "0x000000000022D473030F116dDEE9F6B43aC78BA3"
+
+// signs the ERC-712 typed data, returns the 65-byte signature with v = 27 or 28
+func signTypedData(privateKey *ecdsa.PrivateKey, typedData apitypes.TypedData) ([]byte, error) {
+	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, err
+	}
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, err
+	}
+
+	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
+	signature, err := crypto.Sign(crypto.Keccak256(rawData), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// add 27 to `v` value (last byte)
+	signature[64] += 27
+
+	return signature, nil
+}
+
+func word(value *big.Int) []byte {
+	return math.U256Bytes(new(big.Int).Set(value))
+}
+
+// returns the EIP-712 domain of an EIP-2612 token, or an error if the token does not implement EIP-2612
+func (client *Client) permitDomain(chainId int64, token common.Address) (*apitypes.TypedDataDomain, error) {
+	parsed, err := abi.JSON(bytes.NewReader(erc20))
+	if err != nil {
+		return nil, err
+	}
+
+	call := func(method string, out interface{}) error {
+		response, err := client.Call(ethereum.CallMsg{To: &token, Data: parsed.Methods[method].ID}, nil)
+		if err != nil {
+			return err
+		}
+		return parsed.UnpackIntoInterface(out, method, response)
+	}
+
+	var separator [32]byte
+	if err := call("DOMAIN_SEPARATOR", &separator); err != nil {
+		return nil, errors.New("token does not support EIP-2612")
+	}
+
+	var name string
+	if err := call("name", &name); err != nil {
+		return nil, err
+	}
+
+	// most tokens are on version 1, but some (for example: USDC) implement version()
+	versions := []string{"1", "2"}
+	var version string
+	if err := call("version", &version); err == nil && version != "" {
+		versions = append([]string{version}, versions...)
+	}
+
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+		},
+	}
+	for _, version := range versions {
+		domain := apitypes.TypedDataDomain{
+			Name:              name,
+			Version:           version,
+			ChainId:           math.NewHexOrDecimal256(chainId),
+			VerifyingContract: token.Hex(),
+		}
+		hash, err := typedData.HashStruct("EIP712Domain", domain.Map())
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(hash, separator[:]) {
+			return &domain, nil
+		}
+	}
+
+	return nil, errors.New("token does not support EIP-2612")
+}
+
+// Permit signs an EIP-2612 permit that allows spender to spend (exactly) value of a token until deadline.
+// returns the abi-encoded arguments of IERC20Permit.permit(owner, spender, value, deadline, v, r, s)
+func (client *Client) Permit(privateKey *ecdsa.PrivateKey, chainId int64, token, spender string, value *big.Int, deadline int64) ([]byte, error) {
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	domain, err := client.permitDomain(chainId, common.HexToAddress(token))
+	if err != nil {
+		return nil, err
+	}
+
+	// get the owner's current nonce
+	parsed, err := abi.JSON(bytes.NewReader(erc20))
+	if err != nil {
+		return nil, err
+	}
+	data, err := parsed.Pack("nonces", owner)
+	if err != nil {
+		return nil, err
+	}
+	contract := common.HexToAddress(token)
+	response, err := client.Call(ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var nonce *big.Int
+	if err := parsed.UnpackIntoInterface(&nonce, "nonces", response); err != nil {
+		return nil, err
+	}
+
+	signature, err := signTypedData(privateKey, apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Permit": []apitypes.Type{
+				{Name: "owner", Type: "address"},
+				{Name: "spender", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Permit",
+		Domain:      *domain,
+		Message: apitypes.TypedDataMessage{
+			"owner":    owner.Hex(),
+			"spender":  common.HexToAddress(spender).Hex(),
+			"value":    value.String(),
+			"nonce":    nonce.String(),
+			"deadline": fmt.Sprintf("%d", deadline),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	out = append(out, common.LeftPadBytes(owner.Bytes(), 32)...)
+	out = append(out, common.LeftPadBytes(common.HexToAddress(spender).Bytes(), 32)...)
+	out = append(out, word(value)...)
+	out = append(out, word(big.NewInt(deadline))...)
+	out = append(out, word(big.NewInt(int64(signature[64])))...) // v
+	out = append(out, signature[:32]...)                         // r
+	out = append(out, signature[32:64]...)                       // s
+	return out, nil
+}
+
+// returns the nonce of the owner's Permit2 allowance for this token and spender
+func (client *Client) getPermit2Nonce(owner, token, spender common.Address) (*big.Int, error) {
+	parsed, err := abi.JSON(bytes.NewReader(permit2))
+	if err != nil {
+		return nil, err
+	}
+	data, err := parsed.Pack("allowance", owner, token, spender)
+	if err != nil {
+		return nil, err
+	}
+	contract := common.HexToAddress(Permit2)
+	response, err := client.Call(ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	out, err := parsed.Unpack("allowance", response)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) < 3 {
+		return nil, errors.New("cannot unpack Permit2 allowance")
+	}
+	nonce, ok := out[2].(*big.Int)
+	if !ok {
+		return nil, errors.New("cannot unpack Permit2 nonce")
+	}
+	return nonce, nil
+}
+
+// Permit2 signs a Uniswap Permit2 PermitSingle that allows spender to spend (exactly) amount of a token until expiration.
+// returns the abi-encoded arguments of IPermit2.permit(owner, permitSingle, signature) with a compact (EIP-2098) signature
+func (client *Client) Permit2(privateKey *ecdsa.PrivateKey, chainId int64, token, spender string, amount *big.Int, expiration int64) ([]byte, error) {
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	nonce, err := client.getPermit2Nonce(owner, common.HexToAddress(token), common.HexToAddress(spender))
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signTypedData(privateKey, apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"PermitSingle": []apitypes.Type{
+				{Name: "details", Type: "PermitDetails"},
+				{Name: "spender", Type: "address"},
+				{Name: "sigDeadline", Type: "uint256"},
+			},
+			"PermitDetails": []apitypes.Type{
+				{Name: "token", Type: "address"},
+				{Name: "amount", Type: "uint160"},
+				{Name: "expiration", Type: "uint48"},
+				{Name: "nonce", Type: "uint48"},
+			},
+		},
+		PrimaryType: "PermitSingle",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Permit2",
+			ChainId:           math.NewHexOrDecimal256(chainId),
+			VerifyingContract: Permit2,
+		},
+		Message: apitypes.TypedDataMessage{
+			"details": map[string]interface{}{
+				"token":      common.HexToAddress(token).Hex(),
+				"amount":     amount.String(),
+				"expiration": fmt.Sprintf("%d", expiration),
+				"nonce":      nonce.String(),
+			},
+			"spender":     common.HexToAddress(spender).Hex(),
+			"sigDeadline": fmt.Sprintf("%d", expiration),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// compact signature: vs = s | ((v - 27) << 255)
+	vs := new(big.Int).SetBytes(signature[32:64])
+	if signature[64] == 28 {
+		vs.SetBit(vs, 255, 1)
+	}
+
+	var out []byte
+	out = append(out, common.LeftPadBytes(owner.Bytes(), 32)...)
+	out = append(out, common.LeftPadBytes(common.HexToAddress(token).Bytes(), 32)...)
+	out = append(out, word(amount)...)
+	out = append(out, word(big.NewInt(expiration))...)
+	out = append(out, word(nonce)...)
+	out = append(out, common.LeftPadBytes(common.HexToAddress(spender).Bytes(), 32)...)
+	out = append(out, word(big.NewInt(expiration))...) // sigDeadline
+	out = append(out, word(big.NewInt(256))...)        // offset of the signature
+	out = append(out, word(big.NewInt(64))...)         // length of the signature
+	out = append(out, signature[:32]...)               // r
+	out = append(out, word(vs)...)                     // vs
+	return out, nil
+}
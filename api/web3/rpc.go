@@ -0,0 +1,212 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	geth "github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/svanas/ladder/flag"
+)
+
+var (
+	mutex   sync.Mutex
+	clients = make(map[int64]*Client) // one client per chain, per process
+)
+
+// returns the location of your RPC endpoints, for example ~/.config/ladder/rpc.json
+func path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ladder", "rpc.json"), nil
+}
+
+// returns the RPC endpoints you configured for this chain, if any. the file looks like this:
+// {"1": ["https://eth.example.com", "https://backup.example.com"], "31337": ["http://127.0.0.1:8545"]}
+func configured(chainId int64) ([]string, error) {
+	path, err := path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var endpoints map[string][]string
+	if err := json.Unmarshal(data, &endpoints); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %v", path, err)
+	}
+	return endpoints[strconv.FormatInt(chainId, 10)], nil
+}
+
+// Endpoints returns the RPC endpoints for this chain: --rpc if you included it, otherwise the endpoints you
+// configured in rpc.json, otherwise the public endpoints in our chain registry
+func Endpoints(chainId int64) ([]string, error) {
+	endpoints, err := flag.RPC()
+	if err != nil || len(endpoints) > 0 {
+		return endpoints, err
+	}
+	if endpoints, err = configured(chainId); err != nil || len(endpoints) > 0 {
+		return endpoints, err
+	}
+	return defaultEndpoints(chainId)
+}
+
+// connects to the endpoint, and checks that it is healthy and on the chain we expect
+func dial(endpoint string, chainId int64) (*geth.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := geth.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	if actual.Int64() != chainId {
+		client.Close()
+		return nil, fmt.Errorf("endpoint is on chain %d, not on chain %d", actual.Int64(), chainId)
+	}
+
+	return client, nil
+}
+
+// returns true if the endpoint failed (for example: it is down, or it rate-limits us), rather than the call itself
+func failed(err error) bool {
+	if err == nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == -32005 // limit exceeded
+	}
+	return true
+}
+
+// returns the connection to the endpoint whose turn it is (and the index of that endpoint), connecting to it if we
+// haven't already. the next call goes to the next endpoint, so that your endpoints take turns.
+func (client *Client) connect() (*geth.Client, int, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.conns == nil {
+		client.conns = make([]*geth.Client, len(client.endpoints))
+	}
+	endpoint := client.endpoint
+	client.endpoint = (endpoint + 1) % len(client.endpoints)
+	if client.conns[endpoint] == nil {
+		conn, err := dial(client.endpoints[endpoint], client.chainId)
+		if err != nil {
+			return nil, endpoint, err
+		}
+		client.conns[endpoint] = conn
+	}
+	return client.conns[endpoint], endpoint, nil
+}
+
+// disconnects from the endpoint at this index, so that we dial it again (and check its health) when it has its turn
+func (client *Client) disconnect(endpoint int) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.conns != nil && client.conns[endpoint] != nil {
+		client.conns[endpoint].Close()
+		client.conns[endpoint] = nil
+	}
+}
+
+// disconnects from every endpoint
+func (client *Client) close() {
+	for endpoint := range client.endpoints {
+		client.disconnect(endpoint)
+	}
+}
+
+// do calls fn with the connection to the endpoint whose turn it is. if the endpoint fails (rather than the call), we
+// fail over to the next endpoint and call fn again, until every endpoint has failed.
+func (client *Client) do(fn func(conn *geth.Client) error) error {
+	var errs []error
+	for range client.endpoints {
+		conn, endpoint, err := client.connect()
+		if err == nil {
+			if err = fn(conn); !failed(err) {
+				return err
+			}
+		}
+		errs = append(errs, fmt.Errorf("%s: %v", client.endpoints[endpoint], err))
+		client.disconnect(endpoint)
+	}
+	// every endpoint failed, the next New() will start over (rather than reuse this client)
+	client.forget()
+	return errors.Join(errs...)
+}
+
+// removes this client from our cache
+func (client *Client) forget() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if clients[client.chainId] == client {
+		delete(clients, client.chainId)
+	}
+}
+
+// retry is do for a call that returns a value
+func retry[T any](client *Client, fn func(conn *geth.Client) (T, error)) (T, error) {
+	var out T
+	err := client.do(func(conn *geth.Client) error {
+		var err error
+		out, err = fn(conn)
+		return err
+	})
+	return out, err
+}
+
+// New returns the client of this chain. we cache one client per chain (per process), so that every call shares its
+// connections and its cached reads. your endpoints take turns (round-robin), starting at a random endpoint, so that
+// they share the load even if every process makes a few calls only. if an endpoint fails, the call fails over to the
+// next endpoint. if every endpoint fails, we forget the client, and the next New() starts over.
+func New(chainId int64) (*Client, error) {
+	mutex.Lock()
+	client, ok := clients[chainId]
+	mutex.Unlock()
+	if ok {
+		return client, nil
+	}
+
+	endpoints, err := Endpoints(chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	client = &Client{chainId: chainId, endpoints: endpoints, endpoint: rand.Intn(len(endpoints))}
+	if err := client.do(func(conn *geth.Client) error { return nil }); err != nil {
+		return nil, err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	// another goroutine might have connected in the meantime
+	if other, ok := clients[chainId]; ok {
+		client.close()
+		return other, nil
+	}
+	clients[chainId] = client
+
+	return client, nil
+}
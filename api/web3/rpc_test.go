@@ -0,0 +1,119 @@
+package web3
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	geth "github.com/ethereum/go-ethereum/ethclient"
+)
+
+// returns an endpoint on chain 1 that answers eth_chainId and eth_getBalance, or fails every call after the first `healthy` calls
+func endpoint(t *testing.T, healthy int32, balance string) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) > healthy {
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var request struct {
+			Id     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Error(err)
+			return
+		}
+		response := map[string]any{"jsonrpc": "2.0", "id": request.Id}
+		switch request.Method {
+		case "eth_chainId":
+			response["result"] = "0x1"
+		case "eth_getBalance":
+			response["result"] = balance
+		default:
+			response["error"] = map[string]any{"code": -32601, "message": "method not found"}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRoundRobin(t *testing.T) {
+	first, _ := endpoint(t, 1000, "0x1")
+	second, _ := endpoint(t, 1000, "0x2")
+
+	client := &Client{chainId: 1, endpoints: []string{first.URL, second.URL}}
+	for _, want := range []int64{1, 2, 1, 2} {
+		balance, err := client.GetNativeBalance("0x0000000000000000000000000000000000000001")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if balance.Int64() != want {
+			t.Errorf("expected the balance of endpoint %d, got %v", want, balance)
+		}
+	}
+}
+
+func TestFailover(t *testing.T) {
+	// the first endpoint connects, and then goes down. the second endpoint stays healthy.
+	first, _ := endpoint(t, 1, "0x1")
+	second, calls := endpoint(t, 1000, "0x2")
+
+	client := &Client{chainId: 1, endpoints: []string{first.URL, second.URL}}
+	if _, _, err := client.connect(); err != nil {
+		t.Fatal(err)
+	}
+	client.endpoint = 0 // the first endpoint has the next turn
+
+	for range 3 {
+		balance, err := client.GetNativeBalance("0x0000000000000000000000000000000000000001")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if balance.Int64() != 2 {
+			t.Errorf("expected the balance of the second endpoint, got %v", balance)
+		}
+	}
+	// the second endpoint: one eth_chainId when we connected, and three eth_getBalance
+	if calls.Load() != 4 {
+		t.Errorf("expected 4 calls to the second endpoint, got %d", calls.Load())
+	}
+}
+
+func TestFailoverExhausted(t *testing.T) {
+	first, _ := endpoint(t, 1, "0x1")
+	second, _ := endpoint(t, 0, "0x2")
+
+	client := &Client{chainId: 1, endpoints: []string{first.URL, second.URL}}
+	if err := client.do(func(conn *geth.Client) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	clients[1] = client
+	t.Cleanup(func() { delete(clients, 1) })
+
+	if _, err := client.GetNativeBalance("0x0000000000000000000000000000000000000001"); err == nil {
+		t.Error("expected an error, because every endpoint is down")
+	}
+	if _, ok := clients[1]; ok {
+		t.Error("expected the client to be removed from the cache, because every endpoint is down")
+	}
+}
+
+func TestFailed(t *testing.T) {
+	// a call that the endpoint answered with an error doesn't fail over, because the next endpoint would answer the same
+	server, calls := endpoint(t, 1000, "0x1")
+	client := &Client{chainId: 1, endpoints: []string{server.URL, server.URL}}
+	err := client.do(func(conn *geth.Client) error {
+		return conn.Client().Call(nil, "eth_unknown")
+	})
+	if err == nil || failed(err) {
+		t.Errorf("expected a JSON-RPC error, got %v", err)
+	}
+	// one eth_chainId when we connected, and one eth_unknown
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+}
@@ -0,0 +1,167 @@
+package web3
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	_ "embed"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	geth "github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+//go:embed safe.abi.json
+var safe []byte
+
+// the EIP-1271 magic value, returned by isValidSignature(bytes32,bytes) if the signature is valid
+var magicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+// IsContract returns true if there is code at this address, for example: a Safe
+func (client *Client) IsContract(address string) (bool, error) {
+	code, err := retry(client, func(conn *geth.Client) ([]byte, error) {
+		return conn.CodeAt(context.Background(), common.HexToAddress(address), nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(code) > 0, nil
+}
+
+func (client *Client) callSafe(contract common.Address, method string, out interface{}, args ...interface{}) error {
+	parsed, err := abi.JSON(bytes.NewReader(safe))
+	if err != nil {
+		return err
+	}
+
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+
+	// query the chain
+	response, err := client.Call(ethereum.CallMsg{
+		To:   &contract,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	// unpack the result
+	return parsed.UnpackIntoInterface(out, method, response)
+}
+
+// GetOwners returns the owners of a Safe
+func (client *Client) GetOwners(contract string) ([]common.Address, error) {
+	var owners []common.Address
+	if err := client.callSafe(common.HexToAddress(contract), "getOwners", &owners); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// GetThreshold returns the number of owner signatures a Safe needs
+func (client *Client) GetThreshold(contract string) (int, error) {
+	var threshold *big.Int
+	if err := client.callSafe(common.HexToAddress(contract), "getThreshold", &threshold); err != nil {
+		return 0, err
+	}
+	return int(threshold.Int64()), nil
+}
+
+// IsValidSignature asks a smart-contract wallet whether signature is valid for hash (EIP-1271)
+func (client *Client) IsValidSignature(contract string, hash common.Hash, signature []byte) (bool, error) {
+	var result [4]byte
+	if err := client.callSafe(common.HexToAddress(contract), "isValidSignature", &result, hash, signature); err != nil {
+		return false, err
+	}
+	return result == magicValue, nil
+}
+
+// SafeMessage returns the ERC-712 message a Safe owner signs to have the Safe (v1.3.0 or later) approve hash
+func SafeMessage(chainId int64, contract string, hash common.Hash) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeMessage": []apitypes.Type{
+				{Name: "message", Type: "bytes"},
+			},
+		},
+		PrimaryType: "SafeMessage",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           math.NewHexOrDecimal256(chainId),
+			VerifyingContract: Checksum(contract),
+		},
+		Message: apitypes.TypedDataMessage{
+			"message": hexutil.Encode(hash.Bytes()),
+		},
+	}
+}
+
+// SignSafeMessage signs the SafeMessage that approves hash, returns the 65-byte signature with v = 27 or 28
+func SignSafeMessage(privateKey *ecdsa.PrivateKey, chainId int64, contract string, hash common.Hash) ([]byte, error) {
+	return signTypedData(privateKey, SafeMessage(chainId, contract, hash))
+}
+
+// SafeSignature concatenates the owner signatures, sorted by owner address (as required by the Safe)
+func SafeSignature(signatures map[common.Address][]byte) []byte {
+	var owners []common.Address
+	for owner := range signatures {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		return bytes.Compare(owners[i].Bytes(), owners[j].Bytes()) < 0
+	})
+	var out []byte
+	for _, owner := range owners {
+		out = append(out, signatures[owner]...)
+	}
+	return out
+}
+
+// SafeTransaction is a transaction that a Safe sends
+type SafeTransaction struct {
+	To    string `json:"to"`
+	Value string `json:"value"`
+	Data  string `json:"data"`
+}
+
+// SafeBatch is a batch of transactions, in the JSON format that the Safe{Wallet} Transaction Builder imports. your Safe
+// sends them in one (multisend) transaction, once enough owners have signed it.
+type SafeBatch struct {
+	Version   string `json:"version"`
+	ChainId   string `json:"chainId"`
+	CreatedAt int64  `json:"createdAt"` // in milliseconds
+	Meta      struct {
+		Name                   string `json:"name"`
+		Description            string `json:"description"`
+		CreatedFromSafeAddress string `json:"createdFromSafeAddress"`
+	} `json:"meta"`
+	Transactions []SafeTransaction `json:"transactions"`
+}
+
+// NewSafeBatch returns a batch of transactions for a Safe (on this chain) to send
+func NewSafeBatch(chainId int64, contract, description string, transactions ...SafeTransaction) *SafeBatch {
+	out := &SafeBatch{
+		Version:      "1.0",
+		ChainId:      fmt.Sprintf("%d", chainId),
+		CreatedAt:    time.Now().UnixMilli(),
+		Transactions: transactions,
+	}
+	out.Meta.Name = "ladder"
+	out.Meta.Description = description
+	out.Meta.CreatedFromSafeAddress = Checksum(contract)
+	return out
+}
@@ -0,0 +1,111 @@
+package web3
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// returns the hash a Safe (v1.3.0 or later) computes in getMessageHash, please see
+// https://github.com/safe-global/safe-smart-account/blob/main/contracts/handler/CompatibilityFallbackHandler.sol
+func safeMessageHash(chainId int64, contract common.Address, message []byte) []byte {
+	const (
+		DOMAIN_SEPARATOR_TYPEHASH = "0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218"
+		SAFE_MSG_TYPEHASH         = "0x60b3cbf8b4a223d68d641b3b6ddf9a298e7f33710cf3d3a9d1146b5a6150fbca"
+	)
+	domainSeparator := crypto.Keccak256(
+		common.HexToHash(DOMAIN_SEPARATOR_TYPEHASH).Bytes(),
+		math.U256Bytes(big.NewInt(chainId)),
+		common.LeftPadBytes(contract.Bytes(), 32),
+	)
+	safeMessage := crypto.Keccak256(
+		common.HexToHash(SAFE_MSG_TYPEHASH).Bytes(),
+		crypto.Keccak256(message),
+	)
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, safeMessage)
+}
+
+func TestSignSafeMessage(t *testing.T) {
+	contract := common.HexToAddress("0x1c7C1DdE0C5d0D6A3A5e4B8C8E3f8C1F2f0cE1a9")
+	hash := crypto.Keccak256Hash([]byte("order"))
+
+	for _, test := range []struct {
+		name    string
+		chainId int64
+		key     string
+	}{
+		{"mainnet", 1, "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"},
+		{"arbitrum", 42161, "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"},
+		{"another owner", 1, "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			privateKey, err := crypto.HexToECDSA(test.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signature, err := SignSafeMessage(privateKey, test.chainId, contract.Hex(), hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(signature) != 65 {
+				t.Fatalf("expected 65 bytes, got %d", len(signature))
+			}
+			// the Safe expects v = 27 or 28 for an ECDSA signature
+			if v := signature[64]; v != 27 && v != 28 {
+				t.Fatalf("expected v = 27 or 28, got %d", v)
+			}
+			// the signature recovers to the owner, given the hash the Safe computes
+			sig := append([]byte{}, signature...)
+			sig[64] -= 27
+			publicKey, err := crypto.SigToPub(safeMessageHash(test.chainId, contract, hash.Bytes()), sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signer, owner := crypto.PubkeyToAddress(*publicKey), crypto.PubkeyToAddress(privateKey.PublicKey); signer != owner {
+				t.Errorf("expected the signature of %s, got %s", owner.Hex(), signer.Hex())
+			}
+		})
+	}
+}
+
+func TestSafeSignature(t *testing.T) {
+	var (
+		keys       []*ecdsa.PrivateKey
+		signatures = make(map[common.Address][]byte)
+	)
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+		signatures[crypto.PubkeyToAddress(key.PublicKey)] = bytes.Repeat([]byte{byte(i)}, 65)
+	}
+
+	out := SafeSignature(signatures)
+	if len(out) != 65*len(keys) {
+		t.Fatalf("expected %d bytes, got %d", 65*len(keys), len(out))
+	}
+	// the Safe expects the signatures sorted by owner address, in ascending order
+	var previous common.Address
+	for i := 0; i < len(keys); i++ {
+		var owner common.Address
+		for address, signature := range signatures {
+			if bytes.Equal(signature, out[i*65:(i+1)*65]) {
+				owner = address
+			}
+		}
+		if owner == (common.Address{}) {
+			t.Fatalf("signature %d is not one of ours", i)
+		}
+		if i > 0 && bytes.Compare(previous.Bytes(), owner.Bytes()) >= 0 {
+			t.Errorf("signature %d (of %s) is not sorted after %s", i, owner.Hex(), previous.Hex())
+		}
+		previous = owner
+	}
+}
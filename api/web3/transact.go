@@ -0,0 +1,141 @@
+package web3
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	geth "github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Fee is an EIP-1559 gas estimate for a transaction
+type Fee struct {
+	Gas       uint64   // gas limit
+	GasTipCap *big.Int // max priority fee per gas (in wei)
+	GasFeeCap *big.Int // max fee per gas (in wei)
+}
+
+// Cost returns the maximum amount of native coin (in wei) this transaction will cost
+func (fee *Fee) Cost() *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(fee.Gas), fee.GasFeeCap)
+}
+
+// Estimate returns the EIP-1559 gas estimate for calling a contract
+func (client *Client) Estimate(from, to common.Address, data []byte) (*Fee, error) {
+	ctx := context.Background()
+
+	gas, err := retry(client, func(conn *geth.Client) (uint64, error) {
+		return conn.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &to, Data: data})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tip, err := retry(client, func(conn *geth.Client) (*big.Int, error) {
+		return conn.SuggestGasTipCap(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := retry(client, func(conn *geth.Client) (*types.Header, error) {
+		return conn.HeaderByNumber(ctx, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if head.BaseFee == nil {
+		return nil, errors.New("chain does not support EIP-1559")
+	}
+
+	return &Fee{
+		Gas:       gas,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee, big.NewInt(2))), // allow the base fee to double
+	}, nil
+}
+
+// Transact signs a contract call with your private key and broadcasts it to the network
+func (client *Client) Transact(privateKey *ecdsa.PrivateKey, to common.Address, data []byte) (*types.Transaction, error) {
+	ctx := context.Background()
+
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	fee, err := client.Estimate(from, to, data)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := retry(client, func(conn *geth.Client) (uint64, error) {
+		return conn.PendingNonceAt(ctx, from)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chainId := big.NewInt(client.chainId)
+
+	tx, err := types.SignNewTx(privateKey, types.LatestSignerForChainID(chainId), &types.DynamicFeeTx{
+		ChainID:   chainId,
+		Nonce:     nonce,
+		GasTipCap: fee.GasTipCap,
+		GasFeeCap: fee.GasFeeCap,
+		Gas:       fee.Gas,
+		To:        &to,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// if an endpoint broadcasted our transaction before it failed, the next endpoint already knows our transaction
+	if err := client.do(func(conn *geth.Client) error {
+		if err := conn.SendTransaction(ctx, tx); err != nil && !strings.Contains(err.Error(), "already known") {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	client.invalidate()
+
+	return tx, nil
+}
+
+// WaitMined waits for a transaction to be mined, and returns an error if the transaction reverted
+func (client *Client) WaitMined(tx *types.Transaction) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	ticker := time.NewTicker(3 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := retry(client, func(conn *geth.Client) (*types.Receipt, error) {
+			return conn.TransactionReceipt(ctx, tx.Hash())
+		})
+		if err == nil {
+			client.invalidate()
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+			}
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %s has not been mined yet", tx.Hash().Hex())
+		case <-ticker.C:
+		}
+	}
+}
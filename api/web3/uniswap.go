@@ -0,0 +1,124 @@
+package web3
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed uniswap.abi.json
+var uniswap []byte
+
+// every Uniswap v3 fee tier (in hundredths of a bip)
+var uniswapFees = []int64{100, 500, 3000, 10000}
+
+// returns the address of the Uniswap v3 factory on this chain
+func uniswapFactory(chainId int64) (string, error) {
+	chain, err := GetChain(chainId)
+	if err != nil {
+		return "", err
+	}
+	if chain.UniswapFactory == "" {
+		return "", fmt.Errorf("%s does not have Uniswap v3", chain.Name)
+	}
+	return chain.UniswapFactory, nil
+}
+
+// UniswapV3Price returns the price of base (in quote) in the Uniswap v3 pool with the most liquidity
+func (client *Client) UniswapV3Price(chainId int64, base, quote string, baseDec, quoteDec int) (float64, error) {
+	factory, err := uniswapFactory(chainId)
+	if err != nil {
+		return 0, err
+	}
+
+	parsed, err := abi.JSON(bytes.NewReader(uniswap))
+	if err != nil {
+		return 0, err
+	}
+
+	// find the pools (one per fee tier) in one round-trip
+	contract := common.HexToAddress(factory)
+	var calls []ethereum.CallMsg
+	for _, fee := range uniswapFees {
+		data, err := parsed.Pack("getPool", common.HexToAddress(base), common.HexToAddress(quote), big.NewInt(fee))
+		if err != nil {
+			return 0, err
+		}
+		calls = append(calls, ethereum.CallMsg{To: &contract, Data: data})
+	}
+	client.Prefetch(calls)
+
+	var pools []common.Address
+	for _, call := range calls {
+		response, err := client.Call(call, nil)
+		if err != nil {
+			return 0, err
+		}
+		var pool common.Address
+		if err := parsed.UnpackIntoInterface(&pool, "getPool", response); err != nil {
+			return 0, err
+		}
+		if pool != (common.Address{}) {
+			pools = append(pools, pool)
+		}
+	}
+	if len(pools) == 0 {
+		return 0, errors.New("there is no Uniswap v3 pool for this market")
+	}
+
+	// find the pool with the most liquidity
+	var (
+		best      common.Address
+		liquidity = new(big.Int)
+	)
+	calls = nil
+	for i := range pools {
+		calls = append(calls, ethereum.CallMsg{To: &pools[i], Data: parsed.Methods["liquidity"].ID})
+	}
+	client.Prefetch(calls)
+	for i, call := range calls {
+		response, err := client.Call(call, nil)
+		if err != nil {
+			return 0, err
+		}
+		var value *big.Int
+		if err := parsed.UnpackIntoInterface(&value, "liquidity", response); err != nil {
+			return 0, err
+		}
+		if value.Cmp(liquidity) > 0 {
+			best, liquidity = pools[i], value
+		}
+	}
+	if liquidity.Sign() == 0 {
+		return 0, errors.New("there is no Uniswap v3 liquidity for this market")
+	}
+
+	// read the current price of the pool
+	response, err := client.Call(ethereum.CallMsg{To: &best, Data: parsed.Methods["slot0"].ID}, nil)
+	if err != nil {
+		return 0, err
+	}
+	out, err := parsed.Unpack("slot0", response)
+	if err != nil {
+		return 0, err
+	}
+	sqrtPriceX96, ok := out[0].(*big.Int)
+	if !ok {
+		return 0, errors.New("cannot unpack Uniswap v3 slot0")
+	}
+
+	// the pool prices token1 in token0, where token0 is the token with the lower address
+	sqrtPrice, _ := new(big.Float).Quo(new(big.Float).SetInt(sqrtPriceX96), new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 96))).Float64()
+	price := sqrtPrice * sqrtPrice
+	if bytes.Compare(common.HexToAddress(base).Bytes(), common.HexToAddress(quote).Bytes()) < 0 {
+		return price * math.Pow(10, float64(baseDec-quoteDec)), nil
+	}
+	return (1 / price) * math.Pow(10, float64(baseDec-quoteDec)), nil
+}
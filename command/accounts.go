@@ -0,0 +1,132 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/exchange"
+	"github.com/svanas/ladder/flag"
+	"github.com/svanas/ladder/hdwallet"
+)
+
+func init() {
+	accountsCommand.Flags().String(consts.FLAG_ASSET, "", "base asset")
+	accountsCommand.Flags().String(consts.FLAG_QUOTE, "", "quote asset")
+
+	accountsCommand.Flags().String(consts.FLAG_EXCHANGE, "", "name or code of the exchange")
+	accountsCommand.Flags().Int(consts.FLAG_COUNT, 5, "the number of accounts you derive from your mnemonic")
+
+	rootCommand.AddCommand(&accountsCommand)
+}
+
+var accountsCommand = cobra.Command{
+	Use:   "accounts",
+	Short: "list the balances and open orders of the accounts in your HD wallet",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asset, err := flag.GetString(*cmd, consts.FLAG_ASSET)
+		if err != nil {
+			return err
+		}
+
+		quote, err := flag.GetString(*cmd, consts.FLAG_QUOTE)
+		if err != nil {
+			return err
+		}
+
+		exc, err := func() (exchange.Exchange, error) {
+			exc, err := flag.GetString(*cmd, consts.FLAG_EXCHANGE)
+			if err != nil {
+				return nil, err
+			}
+			return exchange.FindByName(exc)
+		}()
+		if err != nil {
+			return err
+		}
+
+		holder, ok := exc.(exchange.Holder)
+		if !ok {
+			return errors.New("this exchange does not support HD wallets")
+		}
+
+		count, err := cmd.Flags().GetInt(consts.FLAG_COUNT)
+		if err != nil {
+			return err
+		}
+		if count < 1 {
+			return fmt.Errorf("--%s must be 1 or more", consts.FLAG_COUNT)
+		}
+
+		market, err := exc.FormatMarket(asset, quote)
+		if err != nil {
+			return err
+		}
+
+		prec, err := exc.Precision(market, consts.NONE)
+		if err != nil {
+			return err
+		}
+
+		// the first account you list, every next account increments the last component of this path
+		path, err := flag.DerivationPath()
+		if err != nil {
+			return err
+		}
+
+		seed, err := func() ([]byte, error) {
+			mnemonic, err := flag.Mnemonic()
+			if err != nil {
+				return nil, err
+			}
+			return hdwallet.NewSeed(mnemonic)
+		}()
+		if err != nil {
+			return err
+		}
+
+		// returns the number of open orders, and the size that hasn't been filled yet
+		summarize := func(orders []exchange.Order) string {
+			var remaining float64
+			for _, order := range orders {
+				remaining += order.Remaining()
+			}
+			return fmt.Sprintf("%d (%.[3]*[2]f %[4]v)", len(orders), remaining, prec.Size, asset)
+		}
+
+		coin := ""
+		writer := table.NewWriter()
+		next := accounts.DefaultIterator(path)
+		for index := range count {
+			path := next()
+			key, err := hdwallet.Derive(seed, path)
+			if err != nil {
+				return err
+			}
+			address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+			holdings, err := holder.Holdings(market, address)
+			if err != nil {
+				return err
+			}
+			coin = holdings.Coin
+			writer.AppendRow(table.Row{
+				index + 1,
+				path.String(),
+				address,
+				fmt.Sprintf("%.4f", holdings.Native),
+				fmt.Sprintf("%.[2]*[1]f", holdings.Asset, prec.Size),
+				fmt.Sprintf("%.[2]*[1]f", holdings.Quote, prec.Price),
+				summarize(holdings.Buy),
+				summarize(holdings.Sell),
+			})
+		}
+		writer.AppendHeader(table.Row{"", "Path", "Address", coin, asset, quote, "Buy Orders", "Sell Orders"})
+		fmt.Println(writer.Render())
+
+		return nil
+	},
+}
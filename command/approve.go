@@ -0,0 +1,82 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/exchange"
+	"github.com/svanas/ladder/flag"
+	"github.com/svanas/ladder/internal"
+)
+
+func init() {
+	approveCommand.Flags().String(consts.FLAG_EXCHANGE, "", "name or code of the exchange")
+	approveCommand.Flags().String(consts.FLAG_ASSET, "", "the asset you will want to approve")
+	approveCommand.Flags().Float64(consts.FLAG_SIZE, 0, "the (exact) quantity the exchange will be allowed to spend")
+	approveCommand.Flags().String(consts.FLAG_DRY_RUN, "true", "display the output of the command without actually running it")
+	approveCommand.Flags().Lookup(consts.FLAG_DRY_RUN).NoOptDefVal = "true"
+
+	rootCommand.AddCommand(&approveCommand)
+}
+
+var approveCommand = cobra.Command{
+	Use:   "approve",
+	Short: "allow the exchange to spend your asset",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asset, err := flag.GetString(*cmd, consts.FLAG_ASSET)
+		if err != nil {
+			return err
+		}
+
+		size, err := cmd.Flags().GetFloat64(consts.FLAG_SIZE)
+		if err != nil {
+			return err
+		}
+		if size < 0 {
+			return fmt.Errorf("--%s is invalid", consts.FLAG_SIZE)
+		}
+
+		exc, err := func() (exchange.Exchange, error) {
+			exc, err := flag.GetString(*cmd, consts.FLAG_EXCHANGE)
+			if err != nil {
+				return nil, err
+			}
+			return exchange.FindByName(exc)
+		}()
+		if err != nil {
+			return err
+		}
+
+		approver, ok := exc.(exchange.Approver)
+		if !ok {
+			return errors.New("this exchange does not need your approval, there is no need to run this command")
+		}
+
+		dry_run, err := flag.IsDryRun(*cmd)
+		if err != nil {
+			return err
+		}
+
+		amount, err := approver.Scale(asset, size)
+		if err != nil {
+			return err
+		}
+
+		approval, err := approver.Approve(asset, amount, dry_run)
+		if err != nil {
+			return err
+		}
+
+		if dry_run {
+			internal.PrintApproval("Approval (dry run)", approval)
+		} else if approval.Batch != nil {
+			internal.PrintApproval("Safe transaction", approval)
+		} else {
+			internal.PrintApproval("Approved", approval)
+		}
+
+		return nil
+	},
+}
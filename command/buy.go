@@ -1,6 +1,8 @@
 package command
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
 	"github.com/svanas/ladder/answer"
 	consts "github.com/svanas/ladder/constants"
@@ -22,9 +24,25 @@ func init() {
 	buyCommand.Flags().Bool(consts.FLAG_SWEEP_DUST, false, "buy the exact amount you specified, otherwise allow for leftover dust in your wallet")
 
 	buyCommand.Flags().String(consts.FLAG_EXCHANGE, "", "name or code of the exchange")
-	buyCommand.Flags().Bool(consts.FLAG_DRY_RUN, true, "display the output of the command without actually running it")
+	buyCommand.Flags().String(consts.FLAG_DRY_RUN, "true", "display the output of the command without actually running it, or \"validate\" to have the exchange validate every order")
+	buyCommand.Flags().Lookup(consts.FLAG_DRY_RUN).NoOptDefVal = "true"
 	buyCommand.Flags().Bool(consts.FLAG_CANCEL, true, "cancel existing limit orders, if any")
-	buyCommand.Flags().Int(consts.FLAG_DAYS, 0, "number of days your DEX order will be valid")
+	buyCommand.Flags().Bool(consts.FLAG_EPOCH, false, "invalidate every order you have (in every market) with one cheaper transaction that increases your epoch (optional, 1inch-only)")
+	buyCommand.Flags().Int(consts.FLAG_DAYS, 0, "number of days your order will be valid")
+	buyCommand.Flags().Bool(consts.FLAG_POST_ONLY, false, "your orders will only ever be a maker (and never a taker). not supported on 1inch.")
+	buyCommand.Flags().String(consts.FLAG_RECEIVER, "", "address that will receive the proceeds of your orders (optional, 1inch-only)")
+	buyCommand.Flags().String(consts.FLAG_MAKER, "", "the smart-contract wallet (for example: a Safe) you will be trading on behalf of (optional, 1inch-only)")
+	buyCommand.Flags().String(consts.FLAG_ALLOWED_SENDER, "", "the only address that will be allowed to fill your orders (optional, 1inch-only)")
+	buyCommand.Flags().String(consts.FLAG_ORACLE, "", "the Chainlink price feed that --activate-above and --activate-below refer to (optional, 1inch-only)")
+	buyCommand.Flags().Float64(consts.FLAG_ACTIVATE_ABOVE, 0, "your orders can only be filled while the oracle price is above this price (optional, 1inch-only)")
+	buyCommand.Flags().Float64(consts.FLAG_ACTIVATE_BELOW, 0, "your orders can only be filled while the oracle price is below this price (optional, 1inch-only)")
+	buyCommand.Flags().String(consts.FLAG_ACTIVATE_AT, "", "your orders can only be filled from this time on, for example 2030-01-01T12:00:00Z (optional, 1inch-only)")
+	buyCommand.Flags().Float64(consts.FLAG_PREMIUM, 0, "every order starts at this premium (in percent) that decays to zero over --decay (optional, 1inch-only)")
+	buyCommand.Flags().String(consts.FLAG_DECAY, "", "the time it takes for the premium to decay to zero, for example 6h (optional, 1inch-only)")
+	buyCommand.Flags().Bool(consts.FLAG_AUTO_APPROVE, false, "approve the exchange to spend the (exact) total of your orders, if needed")
+	buyCommand.Flags().Int(consts.FLAG_NUDGE, 0, "number of times a rejected post-only order will be nudged one tick away and retried (but never beyond --stop-at-price)")
+
+	hideOutsideOneInch(&buyCommand, consts.FLAG_PREMIUM, consts.FLAG_DECAY)
 
 	rootCommand.AddCommand(&buyCommand)
 }
@@ -53,10 +71,6 @@ var buyCommand = cobra.Command{
 			return err
 		}
 
-		if start_at_price < stop_at_price {
-			stop_at_price, start_at_price = start_at_price, stop_at_price
-		}
-
 		start_with_size, err := flag.GetFloat64(*cmd, consts.START_WITH_SIZE)
 		if err != nil {
 			return err
@@ -77,11 +91,7 @@ var buyCommand = cobra.Command{
 			return err
 		}
 
-		steps := 2
-		for internal.SimulateBuy(start_at_price, stop_at_price, start_with_size, mult, steps) < size {
-			steps++
-		}
-		steps--
+		ladder := internal.NewLadder(consts.BUY, start_at_price, stop_at_price, start_with_size, mult, size, sweep_dust)
 
 		exc, err := func() (exchange.Exchange, error) {
 			exc, err := flag.GetString(*cmd, consts.FLAG_EXCHANGE)
@@ -99,15 +109,43 @@ var buyCommand = cobra.Command{
 			return err
 		}
 
-		prec, err := exc.Precision(market)
+		prec, err := exc.Precision(market, consts.BUY)
 		if err != nil {
 			return err
 		}
 
-		dry_run, err := cmd.Flags().GetBool(consts.FLAG_DRY_RUN)
+		dry_run, err := flag.GetDryRun(*cmd)
+		if err != nil {
+			return err
+		}
+
+		if receiver, err := flag.Receiver(); err != nil {
+			return err
+		} else if receiver != "" && !exchange.IsOneInch(exc) {
+			return errors.New("--" + consts.FLAG_RECEIVER + " is 1inch-only")
+		}
+		if sender, err := flag.AllowedSender(); err != nil {
+			return err
+		} else if sender != "" && !exchange.IsOneInch(exc) {
+			return errors.New("--" + consts.FLAG_ALLOWED_SENDER + " is 1inch-only")
+		}
+		if maker, err := flag.Maker(); err != nil {
+			return err
+		} else if maker != "" && !exchange.IsOneInch(exc) {
+			return errors.New("--" + consts.FLAG_MAKER + " is 1inch-only")
+		}
+		activation, err := flag.GetActivation()
 		if err != nil {
 			return err
 		}
+		if activation != nil && !exchange.IsOneInch(exc) {
+			return errors.New("--" + consts.FLAG_ORACLE + " and --activate-* are 1inch-only")
+		}
+		if auction, err := flag.GetAuction(); err != nil {
+			return err
+		} else if auction != nil && !exchange.IsOneInch(exc) {
+			return errors.New("--" + consts.FLAG_PREMIUM + " and --" + consts.FLAG_DECAY + " are 1inch-only")
+		}
 
 		if asset, err = exc.FormatSymbol(asset); err != nil {
 			return err
@@ -116,7 +154,24 @@ var buyCommand = cobra.Command{
 			return err
 		}
 
-		if !dry_run {
+		days, err := cmd.Flags().GetInt(consts.FLAG_DAYS)
+		if err != nil {
+			return err
+		}
+		if err := internal.CrossChain(exc, days); err != nil {
+			return err
+		}
+
+		post_only, err := cmd.Flags().GetBool(consts.FLAG_POST_ONLY)
+		if err != nil {
+			return err
+		}
+		// a 1inch limit order cannot be a taker, so there is nothing for a post-only order to prevent
+		if post_only && exchange.IsOneInch(exc) {
+			return errors.New("--" + consts.FLAG_POST_ONLY + " is not supported on 1inch")
+		}
+
+		if dry_run == flag.DRY_RUN_FALSE {
 			// cancel existing limit buy orders
 			cancel, err := cmd.Flags().GetBool(consts.FLAG_CANCEL)
 			if err != nil {
@@ -129,50 +184,84 @@ var buyCommand = cobra.Command{
 			}
 			// place new limit buy orders
 			var (
-				all bool // yes to all
-				num int  // result
+				all      bool             // yes to all
+				num      int              // result
+				rejected []exchange.Order // rejected post-only orders
 			)
-			ticker, err := exc.Ticker(market)
+			nudge, err := cmd.Flags().GetInt(consts.FLAG_NUDGE)
 			if err != nil {
 				return err
 			}
-			days, err := cmd.Flags().GetInt(consts.FLAG_DAYS)
+			// skip the orders that would be filled immediately
+			orders, err := ladder.Open(exc, market, *prec, activation != nil)
 			if err != nil {
 				return err
 			}
-			orders := internal.Orders(start_at_price, stop_at_price, (start_with_size / start_at_price), mult, func() *internal.Target {
-				if sweep_dust {
-					return &internal.Target{Side: consts.BUY, Notional: size}
+			// the total of these orders, in the asset the exchange will spend
+			total := internal.Total(consts.BUY, orders)
+			symbol, err := flag.GetString(*cmd, consts.FLAG_QUOTE)
+			if err != nil {
+				return err
+			}
+			// approve the exchange to spend the total of these orders
+			auto_approve, err := cmd.Flags().GetBool(consts.FLAG_AUTO_APPROVE)
+			if err != nil {
+				return err
+			}
+			if approver, ok := exc.(exchange.Approver); ok && auto_approve {
+				if err := internal.Approve(approver, symbol, total); err != nil {
+					return err
 				}
-				return nil
-			}(), steps, *prec)
+			}
+			// sign a permit for the total of these orders, if --permit
+			permit, err := internal.Permit(exc, symbol, total, days)
+			if err != nil {
+				return err
+			}
 			for _, order := range orders {
-				if (order.Price < ticker) || (ticker == -1) {
-					yes := all
-					if !yes {
-						a := internal.Prompt(order, func() string {
-							market, _ := exc.FormatMarket(asset, quote)
-							return market
-						}())
-						yes = a == answer.YES || a == answer.YES_TO_ALL
-						all = all || a == answer.YES_TO_ALL
-					}
-					if yes {
-						if err := exc.Order(market, consts.BUY, order.BigSize(), order.BigPrice(), days); err != nil {
+				yes := all
+				if !yes {
+					a := internal.Prompt(exc, order, func() string {
+						market, _ := exc.FormatMarket(asset, quote)
+						return market
+					}())
+					yes = a == answer.YES || a == answer.YES_TO_ALL
+					all = all || a == answer.YES_TO_ALL
+				}
+				if yes {
+					if err := internal.Place(exc, market, consts.BUY, order, days, post_only, nudge, ladder.StopAtPrice, *prec, permit); err != nil {
+						if !errors.Is(err, exchange.ErrPostOnly) {
 							return err
 						}
+						rejected = append(rejected, order)
+					} else {
 						num++
 					}
 				}
 			}
+			if len(rejected) > 0 {
+				internal.Rejected(rejected, asset, quote, *prec)
+			}
 		}
 
-		internal.Print(asset, quote, start_at_price, stop_at_price, (start_with_size / start_at_price), mult, func() *internal.Target {
-			if sweep_dust {
-				return &internal.Target{Side: consts.BUY, Notional: size}
+		// have the exchange validate every order without placing it
+		var validation []error
+		if dry_run == flag.DRY_RUN_VALIDATE {
+			orders := ladder.Orders(*prec)
+			symbol, err := flag.GetString(*cmd, consts.FLAG_QUOTE)
+			if err != nil {
+				return err
 			}
-			return nil
-		}(), steps, *prec)
+			permit, err := internal.Permit(exc, symbol, internal.Total(consts.BUY, orders), days)
+			if err != nil {
+				return err
+			}
+			for _, order := range orders {
+				validation = append(validation, internal.Validate(exc, market, consts.BUY, order, days, post_only, permit))
+			}
+		}
+
+		ladder.Print(exc, asset, quote, *prec, validation)
 
 		return nil
 	},
@@ -1,7 +1,9 @@
 package command
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
@@ -15,9 +17,12 @@ func init() {
 	cancelCommand.Flags().String(consts.FLAG_QUOTE, "", "quote asset")
 
 	cancelCommand.Flags().String(consts.FLAG_EXCHANGE, "", "name or code of the exchange")
-	cancelCommand.Flags().Bool(consts.FLAG_DRY_RUN, true, "display the output of the command without actually running it")
+	cancelCommand.Flags().String(consts.FLAG_DRY_RUN, "true", "display the output of the command without actually running it")
+	cancelCommand.Flags().Lookup(consts.FLAG_DRY_RUN).NoOptDefVal = "true"
 
 	cancelCommand.Flags().String(consts.FLAG_SIDE, "", "\"buy\" or \"sell\"")
+	cancelCommand.Flags().Bool(consts.FLAG_EPOCH, false, "invalidate every order you have (in every market) with one cheaper transaction that increases your epoch (optional, 1inch-only)")
+	cancelCommand.Flags().String(consts.FLAG_MAKER, "", "the smart-contract wallet (for example: a Safe) you are trading on behalf of (optional, 1inch-only)")
 
 	rootCommand.AddCommand(&cancelCommand)
 }
@@ -63,23 +68,33 @@ var cancelCommand = cobra.Command{
 			return err
 		}
 
+		if maker, err := flag.Maker(); err != nil {
+			return err
+		} else if maker != "" && !exchange.IsOneInch(exc) {
+			return errors.New("--" + consts.FLAG_MAKER + " is 1inch-only")
+		}
+		if flag.Epoch() && !exchange.IsOneInch(exc) {
+			return errors.New("--" + consts.FLAG_EPOCH + " is 1inch-only")
+		}
+
 		market, err := exc.FormatMarket(asset, quote)
 		if err != nil {
 			return err
 		}
 
-		prec, err := exc.Precision(market)
+		prec, err := exc.Precision(market, side)
 		if err != nil {
 			return err
 		}
 
-		dry_run, err := cmd.Flags().GetBool(consts.FLAG_DRY_RUN)
+		dry_run, err := flag.IsDryRun(*cmd)
 		if err != nil {
 			return err
 		}
 
 		if !dry_run {
-			if err := exc.Cancel(market, side); err != nil {
+			// if you are trading on behalf of a Safe, we printed the transaction your Safe will need to send
+			if err := exc.Cancel(market, side); err != nil && !errors.Is(err, exchange.ErrSafeTransaction) {
 				return err
 			}
 		} else {
@@ -88,18 +103,50 @@ var cancelCommand = cobra.Command{
 				return err
 			}
 
+			// include the fill status, expiry and id of every order if the exchange told us about them
+			details := false
+			for _, order := range orders {
+				details = details || order.Id != ""
+			}
+
 			writer := table.NewWriter()
-			writer.AppendHeader(table.Row{"", "Side", "Price", "Size", "Value"})
+			if details {
+				writer.AppendHeader(table.Row{"", "Side", "Price", "Size", "Value", "Filled", "Remaining", "Expiry", "Id"})
+			} else {
+				writer.AppendHeader(table.Row{"", "Side", "Price", "Size", "Value"})
+			}
 
 			for index, order := range orders {
-				writer.AppendRow(table.Row{index + 1, side.String(),
+				row := table.Row{index + 1, side.String(),
 					fmt.Sprintf("%[3]v %.[2]*[1]f", order.Price, prec.Price, quote),
 					fmt.Sprintf("%.[2]*[1]f %[3]v", order.Size, prec.Size, asset),
 					fmt.Sprintf("%[3]v %.[2]*[1]f", (order.Price * order.Size), prec.Price, quote),
-				})
+				}
+				if details {
+					row = append(row,
+						fmt.Sprintf("%.2f%%", order.Filled*100),
+						fmt.Sprintf("%.[2]*[1]f %[3]v", order.Remaining(), prec.Size, asset),
+						func() string {
+							if order.Expiry.IsZero() {
+								return ""
+							}
+							return order.Expiry.Format(time.DateTime)
+						}(),
+						order.Id,
+					)
+				}
+				writer.AppendRow(row)
 			}
 
 			fmt.Println(writer.Render())
+
+			if onchain, ok := exc.(exchange.OnChain); ok && len(orders) > 0 {
+				cost, coin, err := onchain.CancelCost(market, side)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Cancelling these orders will cost at most %v %s in gas\n", cost, coin)
+			}
 		}
 
 		return nil
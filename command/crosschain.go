@@ -0,0 +1,58 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/exchange"
+	"github.com/svanas/ladder/flag"
+)
+
+func init() {
+	crossChainCommand.Flags().String(consts.FLAG_EXCHANGE, "", "name or code of the exchange")
+	crossChainCommand.Flags().String(consts.FLAG_DRY_RUN, "true", "display the output of the command without actually running it")
+	crossChainCommand.Flags().Lookup(consts.FLAG_DRY_RUN).NoOptDefVal = "true"
+
+	rootCommand.AddCommand(&crossChainCommand)
+}
+
+var crossChainCommand = cobra.Command{
+	Use:   "crosschain",
+	Short: "display the status of your cross-chain orders, and reveal their secrets once their escrows have been deployed",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exc, err := func() (exchange.Exchange, error) {
+			exc, err := flag.GetString(*cmd, consts.FLAG_EXCHANGE)
+			if err != nil {
+				return nil, err
+			}
+			return exchange.FindByName(exc)
+		}()
+		if err != nil {
+			return err
+		}
+
+		crossChain, ok := exc.(exchange.CrossChain)
+		if !ok {
+			return errors.New("this exchange does not support cross-chain orders")
+		}
+
+		dry_run, err := flag.IsDryRun(*cmd)
+		if err != nil {
+			return err
+		}
+
+		settlements, err := crossChain.Settle(dry_run)
+
+		writer := table.NewWriter()
+		writer.AppendHeader(table.Row{"", "Market", "Side", "Route", "Order", "Status", "Secrets Revealed"})
+		for index, settlement := range settlements {
+			writer.AppendRow(table.Row{index + 1, settlement.Market, settlement.Side, settlement.Route, settlement.Id, settlement.Status, fmt.Sprintf("%d/%d", settlement.Revealed, settlement.Secrets)})
+		}
+		fmt.Println(writer.Render())
+
+		return err
+	},
+}
@@ -0,0 +1,59 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/exchange"
+	"github.com/svanas/ladder/flag"
+)
+
+func init() {
+	expireCommand.Flags().String(consts.FLAG_EXCHANGE, "", "name or code of the exchange")
+	expireCommand.Flags().String(consts.FLAG_DRY_RUN, "true", "display the output of the command without actually running it")
+	expireCommand.Flags().Lookup(consts.FLAG_DRY_RUN).NoOptDefVal = "true"
+
+	rootCommand.AddCommand(&expireCommand)
+}
+
+var expireCommand = cobra.Command{
+	Use:   "expire",
+	Short: "cancel your expired orders on exchanges that do not support good-til-date",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exc, err := func() (exchange.Exchange, error) {
+			exc, err := flag.GetString(*cmd, consts.FLAG_EXCHANGE)
+			if err != nil {
+				return nil, err
+			}
+			return exchange.FindByName(exc)
+		}()
+		if err != nil {
+			return err
+		}
+
+		expirer, ok := exc.(exchange.Expirer)
+		if !ok {
+			return errors.New("this exchange expires your orders automatically, there is no need to run this command")
+		}
+
+		dry_run, err := flag.IsDryRun(*cmd)
+		if err != nil {
+			return err
+		}
+
+		entries, err := expirer.Expire(dry_run)
+
+		writer := table.NewWriter()
+		writer.AppendHeader(table.Row{"", "Market", "Order", "Expiry"})
+		for index, entry := range entries {
+			writer.AppendRow(table.Row{index + 1, entry.Market, entry.OrderId, time.Unix(entry.Expiry, 0).Format(time.DateTime)})
+		}
+		fmt.Println(writer.Render())
+
+		return err
+	},
+}
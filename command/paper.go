@@ -0,0 +1,186 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/svanas/ladder/api/paper"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/exchange"
+	"github.com/svanas/ladder/flag"
+	"github.com/svanas/ladder/precision"
+)
+
+func init() {
+	paperTickCommand.Flags().String(consts.FLAG_ASSET, "", "base asset")
+	paperTickCommand.Flags().String(consts.FLAG_QUOTE, "", "quote asset")
+	paperTickCommand.Flags().Float64(consts.FLAG_PRICE, 0, "the new market price")
+
+	paperReplayCommand.Flags().String(consts.FLAG_ASSET, "", "base asset")
+	paperReplayCommand.Flags().String(consts.FLAG_QUOTE, "", "quote asset")
+	paperReplayCommand.Flags().String(consts.FLAG_CSV, "", "path to a CSV file with one price per row (in the last column)")
+
+	paperDepositCommand.Flags().String(consts.FLAG_ASSET, "", "the asset you will want to deposit")
+	paperDepositCommand.Flags().Float64(consts.FLAG_SIZE, 0, "the quantity you will want to deposit (negative to withdraw)")
+
+	paperCommand.AddCommand(&paperTickCommand)
+	paperCommand.AddCommand(&paperReplayCommand)
+	paperCommand.AddCommand(&paperDepositCommand)
+	paperCommand.AddCommand(&paperBalanceCommand)
+
+	rootCommand.AddCommand(&paperCommand)
+}
+
+var paperCommand = cobra.Command{
+	Use:   "paper",
+	Short: "manage the paper trading exchange",
+}
+
+func paperMarket(cmd *cobra.Command) (string, error) {
+	asset, err := flag.GetString(*cmd, consts.FLAG_ASSET)
+	if err != nil {
+		return "", err
+	}
+	quote, err := flag.GetString(*cmd, consts.FLAG_QUOTE)
+	if err != nil {
+		return "", err
+	}
+	exc, err := exchange.FindByName("paper")
+	if err != nil {
+		return "", err
+	}
+	return exc.FormatMarket(asset, quote)
+}
+
+func printFills(fills []paper.Fill) {
+	tbl := table.NewWriter()
+	tbl.AppendHeader(table.Row{"", "Market", "Side", "Price", "Size", "Value"})
+	for index, fill := range fills {
+		tbl.AppendRow(table.Row{index + 1, fill.Market, fill.Side.String(), fill.Price, fill.Size, precision.Round(fill.Price*fill.Size, 8)})
+	}
+	fmt.Println(tbl.Render())
+}
+
+var paperTickCommand = cobra.Command{
+	Use:   "tick",
+	Short: "update the market price and fill every crossed order",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		market, err := paperMarket(cmd)
+		if err != nil {
+			return err
+		}
+
+		price, err := flag.GetFloat64(*cmd, consts.FLAG_PRICE)
+		if err != nil {
+			return err
+		}
+
+		client, err := paper.New()
+		if err != nil {
+			return err
+		}
+
+		fills, err := client.Tick(market, price)
+		if err != nil {
+			return err
+		}
+
+		printFills(fills)
+
+		return nil
+	},
+}
+
+var paperReplayCommand = cobra.Command{
+	Use:   "replay",
+	Short: "replay a price feed and fill every crossed order",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		market, err := paperMarket(cmd)
+		if err != nil {
+			return err
+		}
+
+		path, err := flag.GetString(*cmd, consts.FLAG_CSV)
+		if err != nil {
+			return err
+		}
+
+		prices, err := paper.ReadPrices(path)
+		if err != nil {
+			return err
+		}
+
+		client, err := paper.New()
+		if err != nil {
+			return err
+		}
+
+		var all []paper.Fill
+		for _, price := range prices {
+			fills, err := client.Tick(market, price)
+			if err != nil {
+				return err
+			}
+			all = append(all, fills...)
+		}
+
+		printFills(all)
+
+		return nil
+	},
+}
+
+var paperDepositCommand = cobra.Command{
+	Use:   "deposit",
+	Short: "deposit (or withdraw) simulated funds",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asset, err := flag.GetString(*cmd, consts.FLAG_ASSET)
+		if err != nil {
+			return err
+		}
+
+		size, err := flag.GetFloat64(*cmd, consts.FLAG_SIZE)
+		if err != nil {
+			return err
+		}
+
+		client, err := paper.New()
+		if err != nil {
+			return err
+		}
+
+		return client.Deposit(asset, size)
+	},
+}
+
+var paperBalanceCommand = cobra.Command{
+	Use:   "balance",
+	Short: "display your simulated balances",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := paper.New()
+		if err != nil {
+			return err
+		}
+
+		balances := client.Balances()
+
+		var assets []string
+		for asset := range balances {
+			assets = append(assets, asset)
+		}
+		sort.Strings(assets)
+
+		tbl := table.NewWriter()
+		tbl.AppendHeader(table.Row{"Asset", "Total", "Reserved", "Available"})
+		for _, asset := range assets {
+			reserved := client.Reserved(asset)
+			tbl.AppendRow(table.Row{asset, precision.Round(balances[asset], 8), precision.Round(reserved, 8), precision.Round(balances[asset]-reserved, 8)})
+		}
+		fmt.Println(tbl.Render())
+		fmt.Println(client.Path())
+
+		return nil
+	},
+}
@@ -0,0 +1,280 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/svanas/ladder/api/oneinch"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/exchange"
+	"github.com/svanas/ladder/flag"
+	"github.com/svanas/ladder/internal"
+)
+
+func init() {
+	planCommand.Flags().String(consts.FLAG_SIDE, "", "\"buy\" or \"sell\"")
+	planCommand.Flags().String(consts.FLAG_ASSET, "", "name of the asset you will want to sell or buy")
+	planCommand.Flags().String(consts.FLAG_QUOTE, "", "name of the asset you will want to receive or spend")
+
+	planCommand.Flags().Float64(consts.START_AT_PRICE, 0, "price where you will want to start selling or buying at")
+	planCommand.Flags().Float64(consts.STOP_AT_PRICE, 0, "price where you will want to stop selling or buying")
+	planCommand.Flags().Float64(consts.START_WITH_SIZE, 0, "size of your first order (in base asset if you sell, in quote asset if you buy)")
+
+	planCommand.Flags().Float64(consts.FLAG_MULT, 1.05, "multiplier that defines the number of orders and the distance between them")
+	planCommand.Flags().Float64(consts.FLAG_SIZE, 0, "the quantity you will want to sell (in base asset) or buy (in quote asset)")
+	planCommand.Flags().Bool(consts.FLAG_SWEEP_DUST, false, "sell or buy the exact amount you specified, otherwise allow for leftover dust in your wallet")
+
+	planCommand.Flags().String(consts.FLAG_EXCHANGE, "", "name or code of the exchange")
+	planCommand.Flags().Int(consts.FLAG_DAYS, 0, "number of days your order will be valid")
+	planCommand.Flags().String(consts.FLAG_MAKER, "", "the address (or the Safe) that will sign your orders")
+	planCommand.Flags().String(consts.FLAG_RECEIVER, "", "address that will receive the proceeds of your orders (optional)")
+	planCommand.Flags().String(consts.FLAG_ALLOWED_SENDER, "", "the only address that will be allowed to fill your orders (optional)")
+	planCommand.Flags().String(consts.FLAG_ORACLE, "", "the Chainlink price feed that --activate-above and --activate-below refer to (optional)")
+	planCommand.Flags().Float64(consts.FLAG_ACTIVATE_ABOVE, 0, "your orders can only be filled while the oracle price is above this price (optional)")
+	planCommand.Flags().Float64(consts.FLAG_ACTIVATE_BELOW, 0, "your orders can only be filled while the oracle price is below this price (optional)")
+	planCommand.Flags().String(consts.FLAG_ACTIVATE_AT, "", "your orders can only be filled from this time on, for example 2030-01-01T12:00:00Z (optional)")
+	planCommand.Flags().Float64(consts.FLAG_PREMIUM, 0, "every order starts at this premium (in percent) that decays to zero over --decay (optional)")
+	planCommand.Flags().String(consts.FLAG_DECAY, "", "the time it takes for the premium to decay to zero, for example 6h (optional)")
+	planCommand.Flags().String(consts.FLAG_EXPORT, "", "path to the file your unsigned orders will be written to")
+
+	signCommand.Flags().String(consts.FLAG_PLAN, "", "path to the file with your unsigned orders")
+
+	submitCommand.Flags().String(consts.FLAG_PLAN, "", "path to the file with your signed orders")
+	submitCommand.Flags().String(consts.FLAG_DRY_RUN, "true", "display the output of the command without actually running it")
+	submitCommand.Flags().Lookup(consts.FLAG_DRY_RUN).NoOptDefVal = "true"
+
+	rootCommand.AddCommand(&planCommand)
+	rootCommand.AddCommand(&signCommand)
+	rootCommand.AddCommand(&submitCommand)
+}
+
+func printPlan(plan *oneinch.Plan) {
+	tbl := table.NewWriter()
+	tbl.AppendHeader(table.Row{"", "Maker Asset", "Making Amount", "Taker Asset", "Taking Amount", "Order Hash", "Signed"})
+	for index, order := range plan.Orders {
+		tbl.AppendRow(table.Row{index + 1,
+			order.Data.MakerAsset,
+			order.Data.MakingAmount,
+			order.Data.TakerAsset,
+			order.Data.TakingAmount,
+			order.OrderHash,
+			func() interface{} {
+				if plan.Threshold > 0 {
+					return fmt.Sprintf("%d/%d", len(order.Signatures), plan.Threshold)
+				}
+				return order.Signed()
+			}(),
+		})
+	}
+	fmt.Println(tbl.Render())
+}
+
+var planCommand = cobra.Command{
+	Use:   "plan",
+	Short: "export your unsigned orders, to be signed on an air-gapped machine",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		side, err := func() (consts.OrderSide, error) {
+			side, err := flag.GetString(*cmd, consts.FLAG_SIDE)
+			if err != nil {
+				return consts.NONE, err
+			} else if side == "buy" {
+				return consts.BUY, nil
+			} else if side == "sell" {
+				return consts.SELL, nil
+			} else {
+				return consts.NONE, fmt.Errorf("--%s is invalid. valid values are \"buy\" or \"sell\"", consts.FLAG_SIDE)
+			}
+		}()
+		if err != nil {
+			return err
+		}
+
+		asset, err := flag.GetString(*cmd, consts.FLAG_ASSET)
+		if err != nil {
+			return err
+		}
+
+		quote, err := flag.GetString(*cmd, consts.FLAG_QUOTE)
+		if err != nil {
+			return err
+		}
+
+		start_at_price, err := flag.GetFloat64(*cmd, consts.START_AT_PRICE)
+		if err != nil {
+			return err
+		}
+
+		stop_at_price, err := flag.GetFloat64(*cmd, consts.STOP_AT_PRICE)
+		if err != nil {
+			return err
+		}
+
+		start_with_size, err := flag.GetFloat64(*cmd, consts.START_WITH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		mult, err := flag.Mult(*cmd)
+		if err != nil {
+			return err
+		}
+
+		size, err := flag.GetFloat64(*cmd, consts.FLAG_SIZE)
+		if err != nil {
+			return err
+		}
+
+		sweep_dust, err := cmd.Flags().GetBool(consts.FLAG_SWEEP_DUST)
+		if err != nil {
+			return err
+		}
+
+		days, err := cmd.Flags().GetInt(consts.FLAG_DAYS)
+		if err != nil {
+			return err
+		}
+
+		path, err := flag.GetString(*cmd, consts.FLAG_EXPORT)
+		if err != nil {
+			return err
+		}
+
+		if _, err := flag.Receiver(); err != nil {
+			return err
+		}
+		if _, err := flag.AllowedSender(); err != nil {
+			return err
+		}
+		activation, err := flag.GetActivation()
+		if err != nil {
+			return err
+		}
+		if _, err := flag.GetAuction(); err != nil {
+			return err
+		}
+
+		ladder := internal.NewLadder(side, start_at_price, stop_at_price, start_with_size, mult, size, sweep_dust)
+
+		exc, err := func() (exchange.Exchange, error) {
+			exc, err := flag.GetString(*cmd, consts.FLAG_EXCHANGE)
+			if err != nil {
+				return nil, err
+			}
+			return exchange.FindByName(exc)
+		}()
+		if err != nil {
+			return err
+		}
+
+		planner, ok := exc.(exchange.Planner)
+		if !ok {
+			return errors.New("this exchange does not support air-gapped signing")
+		}
+
+		market, err := exc.FormatMarket(asset, quote)
+		if err != nil {
+			return err
+		}
+
+		prec, err := exc.Precision(market, side)
+		if err != nil {
+			return err
+		}
+
+		// skip the orders that would be filled immediately
+		orders, err := ladder.Open(exc, market, *prec, activation != nil)
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			return errors.New("every order would be filled immediately, there is nothing to plan")
+		}
+
+		if err := planner.Plan(market, side, orders, days, path); err != nil {
+			return err
+		}
+
+		if asset, err = exc.FormatSymbol(asset); err != nil {
+			return err
+		}
+		if quote, err = exc.FormatSymbol(quote); err != nil {
+			return err
+		}
+
+		ladder.Print(exc, asset, quote, *prec, nil)
+
+		fmt.Printf("Exported %d unsigned order(s) to %s\n", len(orders), path)
+
+		return nil
+	},
+}
+
+var signCommand = cobra.Command{
+	Use:   "sign",
+	Short: "sign your exported orders (does not need an internet connection)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := flag.GetString(*cmd, consts.FLAG_PLAN)
+		if err != nil {
+			return err
+		}
+
+		plan, err := oneinch.LoadPlan(path)
+		if err != nil {
+			return err
+		}
+
+		privateKey, err := flag.PrivateKey()
+		if err != nil {
+			return err
+		}
+
+		if err := plan.Sign(privateKey); err != nil {
+			return err
+		}
+
+		if err := plan.Save(path); err != nil {
+			return err
+		}
+
+		printPlan(plan)
+
+		return nil
+	},
+}
+
+var submitCommand = cobra.Command{
+	Use:   "submit",
+	Short: "post your signed orders",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := flag.GetString(*cmd, consts.FLAG_PLAN)
+		if err != nil {
+			return err
+		}
+
+		plan, err := oneinch.LoadPlan(path)
+		if err != nil {
+			return err
+		}
+
+		if err := plan.Verify(); err != nil {
+			return err
+		}
+
+		dry_run, err := flag.IsDryRun(*cmd)
+		if err != nil {
+			return err
+		}
+
+		if !dry_run {
+			if err := plan.Submit(); err != nil {
+				return err
+			}
+		}
+
+		printPlan(plan)
+
+		return nil
+	},
+}
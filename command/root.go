@@ -1,21 +1,55 @@
 package command
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/exchange"
+	"github.com/svanas/ladder/flag"
 )
 
 func init() {
 	rootCommand.PersistentFlags().String(consts.FLAG_API_KEY, "", "your API key (optional, CEX-only)")
 	rootCommand.PersistentFlags().String(consts.FLAG_API_SECRET, "", "your API secret (optional, CEX-only)")
+	rootCommand.PersistentFlags().Bool(consts.FLAG_SANDBOX, false, "use the exchange's sandbox or testnet (optional, CEX-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_BASE_URL, "", "override the scheme and host of the exchange's API, for example http://localhost:8080. the exchange's API path (for example /api/v2 on Bitstamp) is appended to it (optional, CEX-only)")
 	rootCommand.PersistentFlags().Int(consts.FLAG_CHAIN_ID, 0, "chain ID (optional, please see https://chainlist.org)")
+	rootCommand.PersistentFlags().Int(consts.FLAG_DST_CHAIN_ID, 0, "the chain ID your orders settle on, if it isn't --chain-id (optional, 1inch-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_RPC, "", "comma-separated list of RPC endpoints (optional, DEX-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_PRICE_SOURCE, "", "where the market price comes from: \"1inch\", \"uniswap\" or \"coingecko\" (optional, DEX-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_ASSET_ADDRESS, "", "the address of your --asset, if its symbol is ambiguous (optional, DEX-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_QUOTE_ADDRESS, "", "the address of your --quote, if its symbol is ambiguous (optional, DEX-only)")
 	rootCommand.PersistentFlags().String(consts.FLAG_PRIVATE_KEY, "", "your private key (optional, DEX-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_KEYSTORE, "", "path to your encrypted keystore, instead of your private key (optional, DEX-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_MNEMONIC, "", "derive your private key from your mnemonic, for example --mnemonic=\"word1 word2 ...\", or --mnemonic=- to be prompted for it (optional, DEX-only)")
+	rootCommand.PersistentFlags().Uint(consts.FLAG_ACCOUNT_INDEX, 0, "the index of the account you derive from your mnemonic (optional, DEX-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_DERIVATION, "", "the derivation path of the account you derive from your mnemonic, for example m/44'/60'/0'/0/0 (optional, DEX-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_PERMIT, "", "sign an \"eip2612\" or \"permit2\" permit instead of approving your asset on-chain (optional, DEX-only)")
+	rootCommand.PersistentFlags().String(consts.FLAG_PAPER_STATE, "", "path to your paper trading state file (optional, paper-only)")
 	rootCommand.CompletionOptions.HiddenDefaultCmd = true
 }
 
 var rootCommand = cobra.Command{
 	Use:   "ladder",
 	Short: "incremental buying or selling of any crypto asset",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		flag.Parse(cmd)
+	},
+}
+
+// hides these (1inch-only) flags from --help, if you ask for help on another --exchange
+func hideOutsideOneInch(cmd *cobra.Command, names ...string) {
+	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		if name, err := cmd.Flags().GetString(consts.FLAG_EXCHANGE); err == nil && name != "" {
+			if exc, err := exchange.FindByName(name); err == nil && !exchange.IsOneInch(exc) {
+				for _, name := range names {
+					cmd.Flags().MarkHidden(name)
+				}
+			}
+		}
+		rootCommand.HelpFunc()(cmd, args)
+	})
 }
 
 // Returns true if you are running a development build (not a release build), otherwise false.
@@ -23,7 +57,25 @@ func development() bool {
 	return rootCommand.Version == "99.99.999"
 }
 
+// none of our commands take arguments. we don't echo them, because they might be the words of your mnemonic.
+func noArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%s doesn't take arguments, please quote the value of a flag that contains spaces, for example --%s=\"word1 word2 ...\"", cmd.CommandPath(), consts.FLAG_MNEMONIC)
+	}
+	return nil
+}
+
 func Execute(version string) error {
 	rootCommand.Version = version
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		if cmd.Args == nil && cmd.Runnable() {
+			cmd.Args = noArgs
+		}
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(&rootCommand)
 	return rootCommand.Execute()
 }
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/svanas/ladder/flag"
+)
+
+func init() {
+	walletCommand.AddCommand(&walletNewCommand)
+	walletCommand.AddCommand(&walletImportCommand)
+	walletCommand.AddCommand(&walletListCommand)
+
+	rootCommand.AddCommand(&walletCommand)
+}
+
+var walletCommand = cobra.Command{
+	Use:   "wallet",
+	Short: "manage the encrypted keystores you can use with --keystore",
+}
+
+// returns the keystore in your config directory, for example ~/.config/ladder/keystore
+func keyStore() (*keystore.KeyStore, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return keystore.NewKeyStore(filepath.Join(dir, "ladder", "keystore"), keystore.StandardScryptN, keystore.StandardScryptP), nil
+}
+
+var walletNewCommand = cobra.Command{
+	Use:   "new",
+	Short: "generate a new private key, and encrypt it with a passphrase",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ks, err := keyStore()
+		if err != nil {
+			return err
+		}
+
+		passphrase, err := flag.Passphrase(true)
+		if err != nil {
+			return err
+		}
+
+		account, err := ks.NewAccount(passphrase)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Created %s in %s\n", account.Address.Hex(), account.URL.Path)
+
+		return nil
+	},
+}
+
+var walletImportCommand = cobra.Command{
+	Use:   "import",
+	Short: "encrypt your existing private key with a passphrase",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ks, err := keyStore()
+		if err != nil {
+			return err
+		}
+
+		privateKey, err := flag.PrivateKey()
+		if err != nil {
+			return err
+		}
+
+		ecdsaPrivateKey, err := crypto.ToECDSA(privateKey)
+		if err != nil {
+			return err
+		}
+
+		passphrase, err := flag.Passphrase(true)
+		if err != nil {
+			return err
+		}
+
+		account, err := ks.ImportECDSA(ecdsaPrivateKey, passphrase)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Imported %s in %s\n", account.Address.Hex(), account.URL.Path)
+
+		return nil
+	},
+}
+
+var walletListCommand = cobra.Command{
+	Use:   "list",
+	Short: "display your encrypted keystores",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ks, err := keyStore()
+		if err != nil {
+			return err
+		}
+
+		tbl := table.NewWriter()
+		tbl.AppendHeader(table.Row{"", "Address", "Keystore"})
+		for index, account := range ks.Accounts() {
+			tbl.AppendRow(table.Row{index + 1, account.Address.Hex(), account.URL.Path})
+		}
+		fmt.Println(tbl.Render())
+
+		return nil
+	},
+}
@@ -3,23 +3,54 @@ package constants
 import "time"
 
 const (
-	FLAG_API_KEY     = "api-key"
-	FLAG_API_SECRET  = "api-secret"
-	FLAG_ASSET       = "asset"
-	FLAG_QUOTE       = "quote"
-	START_AT_PRICE   = "start-at-price"
-	STOP_AT_PRICE    = "stop-at-price"
-	START_WITH_SIZE  = "start-with-size"
-	FLAG_MULT        = "mult"
-	FLAG_SIZE        = "size"
-	FLAG_SWEEP_DUST  = "sweep-dust"
-	FLAG_EXCHANGE    = "exchange"
-	FLAG_DRY_RUN     = "dry-run"
-	FLAG_SIDE        = "side"
-	FLAG_CHAIN_ID    = "chain-id"
-	FLAG_PRIVATE_KEY = "private-key"
-	FLAG_CANCEL      = "cancel"
-	FLAG_DAYS        = "days"
+	FLAG_API_KEY        = "api-key"
+	FLAG_API_SECRET     = "api-secret"
+	FLAG_ASSET          = "asset"
+	FLAG_QUOTE          = "quote"
+	START_AT_PRICE      = "start-at-price"
+	STOP_AT_PRICE       = "stop-at-price"
+	START_WITH_SIZE     = "start-with-size"
+	FLAG_MULT           = "mult"
+	FLAG_SIZE           = "size"
+	FLAG_SWEEP_DUST     = "sweep-dust"
+	FLAG_EXCHANGE       = "exchange"
+	FLAG_DRY_RUN        = "dry-run"
+	FLAG_SIDE           = "side"
+	FLAG_CHAIN_ID       = "chain-id"
+	FLAG_PRIVATE_KEY    = "private-key"
+	FLAG_CANCEL         = "cancel"
+	FLAG_DAYS           = "days"
+	FLAG_PAPER_STATE    = "paper-state"
+	FLAG_PRICE          = "price"
+	FLAG_CSV            = "csv"
+	FLAG_SANDBOX        = "sandbox"
+	FLAG_BASE_URL       = "base-url"
+	FLAG_POST_ONLY      = "post-only"
+	FLAG_NUDGE          = "nudge"
+	FLAG_AUTO_APPROVE   = "auto-approve"
+	FLAG_PERMIT         = "permit"
+	FLAG_RECEIVER       = "receiver"
+	FLAG_ALLOWED_SENDER = "allowed-sender"
+	FLAG_MAKER          = "maker"
+	FLAG_EXPORT         = "export"
+	FLAG_PLAN           = "plan"
+	FLAG_KEYSTORE       = "keystore"
+	FLAG_RPC            = "rpc"
+	FLAG_ASSET_ADDRESS  = "asset-address"
+	FLAG_QUOTE_ADDRESS  = "quote-address"
+	FLAG_PRICE_SOURCE   = "price-source"
+	FLAG_ORACLE         = "oracle"
+	FLAG_ACTIVATE_ABOVE = "activate-above"
+	FLAG_ACTIVATE_BELOW = "activate-below"
+	FLAG_ACTIVATE_AT    = "activate-at"
+	FLAG_PREMIUM        = "premium"
+	FLAG_DECAY          = "decay"
+	FLAG_MNEMONIC       = "mnemonic"
+	FLAG_ACCOUNT_INDEX  = "account-index"
+	FLAG_DERIVATION     = "derivation-path"
+	FLAG_COUNT          = "count"
+	FLAG_DST_CHAIN_ID   = "dst-chain-id"
+	FLAG_EPOCH          = "epoch"
 )
 
 const (
@@ -2,12 +2,14 @@
 package exchange
 
 import (
+	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
 
 	"github.com/svanas/ladder/api/binance"
 	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/journal"
 )
 
 type Binance struct {
@@ -48,25 +50,99 @@ func (self *Binance) Info() *info {
 	return self.info
 }
 
-func (self *Binance) Order(market string, side consts.OrderSide, size, price big.Float, days int) error {
+func (self *Binance) Order(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
 	client, err := binance.ReadWrite()
 	if err != nil {
 		return err
 	}
 
-	if _, err := client.CreateOrder(market, side, func() float64 {
+	order, err := client.CreateOrder(market, side, func() float64 {
 		out, _ := size.Float64()
 		return out
 	}(), func() float64 {
 		out, _ := price.Float64()
 		return out
-	}()); err != nil {
+	}(), postOnly)
+	if err != nil {
+		if binance.IsPostOnlyRejected(err) {
+			return fmt.Errorf("%w: %v", ErrPostOnly, err)
+		}
 		return err
 	}
 
+	// binance doesn't support good-til-date on spot, so we keep track of the expiry ourselves
+	if expiry := expiresAt(days); !expiry.IsZero() {
+		return journal.Add(journal.Entry{
+			Exchange: self.name,
+			Market:   market,
+			OrderId:  strconv.FormatInt(order.OrderID, 10),
+			Expiry:   expiry.Unix(),
+		})
+	}
+
 	return nil
 }
 
+func (self *Binance) Expire(dry_run bool) ([]journal.Entry, error) {
+	entries, err := journal.Get(self.name)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []journal.Entry
+	for _, entry := range entries {
+		if entry.Expired() {
+			expired = append(expired, entry)
+		}
+	}
+	if dry_run || len(entries) == 0 {
+		return expired, nil
+	}
+
+	client, err := binance.ReadWrite()
+	if err != nil {
+		return nil, err
+	}
+
+	// orders that have been filled or cancelled before they expired no longer need to be kept track of
+	markets := make(map[string]bool)
+	open := make(map[string]bool) // --> order ids
+	for _, entry := range entries {
+		if markets[entry.Market] {
+			continue
+		}
+		markets[entry.Market] = true
+		orders, err := client.GetOpenOrders(entry.Market)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range orders {
+			open[strconv.FormatInt(order.OrderID, 10)] = true
+		}
+	}
+	if err := journal.Prune(self.name, func(entry journal.Entry) bool {
+		return entry.Expired() || open[entry.OrderId]
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range expired {
+		orderId, err := strconv.ParseInt(entry.OrderId, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		// the order might have been filled or cancelled already, in which case there is nothing to cancel
+		if err := client.CancelOrder(entry.Market, orderId); err != nil && !binance.IsUnknownOrder(err) {
+			return nil, err
+		}
+		if err := journal.Remove(self.name, entry.OrderId); err != nil {
+			return nil, err
+		}
+	}
+
+	return expired, nil
+}
+
 func (self *Binance) Orders(market string, side consts.OrderSide) ([]Order, error) {
 	client, err := binance.ReadWrite()
 	if err != nil {
@@ -99,7 +175,7 @@ func (self *Binance) Orders(market string, side consts.OrderSide) ([]Order, erro
 	return output, nil
 }
 
-func (self *Binance) Precision(symbol string) (*Precision, error) {
+func (self *Binance) Precision(symbol string, side consts.OrderSide) (*Precision, error) {
 	client, err := binance.ReadOnly()
 	if err != nil {
 		return nil, err
@@ -114,7 +190,7 @@ func (self *Binance) Precision(symbol string) (*Precision, error) {
 	}, nil
 }
 
-func (self *Binance) Ticker(market string) (float64, error) {
+func (self *Binance) Ticker(market string, side consts.OrderSide) (float64, error) {
 	client, err := binance.ReadOnly()
 	if err != nil {
 		return 0, err
@@ -122,6 +198,20 @@ func (self *Binance) Ticker(market string) (float64, error) {
 	return client.GetTicker(market)
 }
 
+func (self *Binance) Validate(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
+	client, err := binance.ReadWrite()
+	if err != nil {
+		return err
+	}
+	return client.TestOrder(market, side, func() float64 {
+		out, _ := size.Float64()
+		return out
+	}(), func() float64 {
+		out, _ := price.Float64()
+		return out
+	}(), postOnly)
+}
+
 func newBinance() Exchange {
 	return &Binance{
 		info: &info{
@@ -49,7 +49,7 @@ func (self *Bitstamp) Info() *info {
 	return self.info
 }
 
-func (self *Bitstamp) Order(market string, side consts.OrderSide, size, price big.Float, days int) error {
+func (self *Bitstamp) Order(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
 	client, err := bitstamp.ReadWrite()
 	if err != nil {
 		return err
@@ -60,12 +60,15 @@ func (self *Bitstamp) Order(market string, side consts.OrderSide, size, price bi
 
 	if _, err := func() (*bitstamp.Order, error) {
 		if side == consts.BUY {
-			return client.BuyLimitOrder(market, s, p)
+			return client.BuyLimitOrder(market, s, p, expiresAt(days), postOnly)
 		} else if side == consts.SELL {
-			return client.SellLimitOrder(market, s, p)
+			return client.SellLimitOrder(market, s, p, expiresAt(days), postOnly)
 		}
 		return nil, fmt.Errorf("unknown order side %v", side)
 	}(); err != nil {
+		if bitstamp.IsPostOnlyRejected(err) {
+			return fmt.Errorf("%w: %v", ErrPostOnly, err)
+		}
 		return err
 	}
 
@@ -96,8 +99,12 @@ func (self *Bitstamp) Orders(market string, side consts.OrderSide) ([]Order, err
 	return output, nil
 }
 
-func (self *Bitstamp) Precision(market string) (*Precision, error) {
-	pair, err := bitstamp.ReadOnly().GetPair(market)
+func (self *Bitstamp) Precision(market string, side consts.OrderSide) (*Precision, error) {
+	client, err := bitstamp.ReadOnly()
+	if err != nil {
+		return nil, err
+	}
+	pair, err := client.GetPair(market)
 	if err != nil {
 		return nil, err
 	}
@@ -107,14 +114,32 @@ func (self *Bitstamp) Precision(market string) (*Precision, error) {
 	}, nil
 }
 
-func (self *Bitstamp) Ticker(market string) (float64, error) {
-	ticker, err := bitstamp.ReadOnly().Ticker(market)
+func (self *Bitstamp) Ticker(market string, side consts.OrderSide) (float64, error) {
+	client, err := bitstamp.ReadOnly()
+	if err != nil {
+		return 0, err
+	}
+	ticker, err := client.Ticker(market)
 	if err != nil {
 		return 0, err
 	}
 	return strconv.ParseFloat(ticker.Last, 64)
 }
 
+func (self *Bitstamp) Validate(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
+	client, err := bitstamp.ReadOnly()
+	if err != nil {
+		return err
+	}
+	pair, err := client.GetPair(market)
+	if err != nil {
+		return err
+	}
+	s, _ := size.Float64()
+	p, _ := price.Float64()
+	return pair.Validate(s, p)
+}
+
 func newBitstamp() Exchange {
 	return &Bitstamp{
 		info: &info{
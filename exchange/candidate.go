@@ -0,0 +1,119 @@
+package exchange
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/svanas/ladder/answer"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/tokenlist"
+)
+
+// candidate is one of the tokens a symbol can refer to
+type candidate struct {
+	coin
+	symbol    string
+	name      string
+	verified  bool    // true if the token is on one of the token lists
+	marketCap float64 // in USD, zero if unknown
+}
+
+// returns the tokens this symbol can refer to, best candidate first. if the symbol is on one of the token lists, the
+// tokens on the token lists are the only candidates. otherwise, the candidates are ranked by their market cap.
+func (dex *dex) candidates(chainId int64, symbol string) ([]candidate, error) {
+	tokens, err := tokenlist.FindAll(chainId, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) > 0 {
+		var out []candidate
+		for i := range tokens {
+			out = append(out, candidate{
+				coin:     coin{address: tokens[i].Address, token: &tokens[i]},
+				symbol:   tokens[i].Symbol,
+				name:     tokens[i].Name,
+				verified: true,
+			})
+		}
+		return out, nil
+	}
+
+	matches, err := dex.coingecko.GetCoins(symbol, chainId)
+	if err != nil {
+		return nil, err
+	}
+	var out []candidate
+	for _, match := range matches {
+		out = append(out, candidate{
+			coin:   coin{id: match.Id, address: match.Address},
+			symbol: match.Symbol,
+			name:   match.Id,
+		})
+	}
+	if len(out) > 1 {
+		var ids []string
+		for _, match := range matches {
+			ids = append(ids, match.Id)
+		}
+		if marketCaps, err := dex.coingecko.GetMarketCaps(ids); err == nil {
+			for i := range out {
+				out[i].marketCap = marketCaps[out[i].id]
+			}
+		}
+		sort.SliceStable(out, func(i, j int) bool {
+			return out[i].marketCap > out[j].marketCap
+		})
+	}
+	return out, nil
+}
+
+// returns the token this symbol refers to. if the symbol is ambiguous, we ask you to choose (or to pin an address).
+func (dex *dex) resolve(chainId int64, symbol string) (*coin, error) {
+	key := fmt.Sprintf("%d:%s", chainId, strings.ToUpper(symbol))
+	if chosen, ok := dex.chosen[key]; ok {
+		out := *chosen
+		return &out, nil
+	}
+
+	candidates, err := dex.candidates(chainId, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 1 {
+		return &candidates[0].coin, nil
+	}
+
+	tbl := table.NewWriter()
+	tbl.AppendHeader(table.Row{"", "Symbol", "Name", "Address", "Token List", "Market Cap"})
+	for i, candidate := range candidates {
+		tbl.AppendRow(table.Row{i + 1, candidate.symbol, candidate.name, candidate.address, candidate.verified, func() string {
+			if candidate.marketCap > 0 {
+				return fmt.Sprintf("$%.0f", candidate.marketCap)
+			}
+			return ""
+		}()})
+	}
+
+	if !answer.Interactive() {
+		return nil, fmt.Errorf("%s is ambiguous on chain %d, please include --%s or --%s with your command line\n%s",
+			symbol, chainId, consts.FLAG_ASSET_ADDRESS, consts.FLAG_QUOTE_ADDRESS, tbl.Render())
+	}
+
+	fmt.Printf("%s is ambiguous on chain %d, which one of these tokens do you mean?\n", symbol, chainId)
+	fmt.Println(tbl.Render())
+	i := answer.Choose(len(candidates))
+	if i < 0 {
+		return nil, fmt.Errorf("%s is ambiguous on chain %d, please include --%s or --%s with your command line",
+			symbol, chainId, consts.FLAG_ASSET_ADDRESS, consts.FLAG_QUOTE_ADDRESS)
+	}
+
+	if dex.chosen == nil {
+		dex.chosen = make(map[string]*coin)
+	}
+	dex.chosen[key] = &candidates[i].coin
+
+	out := candidates[i].coin
+	return &out, nil
+}
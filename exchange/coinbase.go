@@ -46,7 +46,7 @@ func (self *Coinbase) Info() *info {
 	return self.info
 }
 
-func (self *Coinbase) Order(market string, side consts.OrderSide, size, price big.Float, days int) error {
+func (self *Coinbase) Order(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
 	client, err := coinbase.New()
 	if err != nil {
 		return err
@@ -57,7 +57,10 @@ func (self *Coinbase) Order(market string, side consts.OrderSide, size, price bi
 	}(), func() float64 {
 		out, _ := price.Float64()
 		return out
-	}()); err != nil {
+	}(), expiresAt(days), postOnly); err != nil {
+		if coinbase.IsPostOnlyRejected(err) {
+			return fmt.Errorf("%w: %v", ErrPostOnly, err)
+		}
 		return err
 	}
 	return nil
@@ -76,10 +79,10 @@ func (self *Coinbase) Orders(market string, side consts.OrderSide) ([]Order, err
 
 	var output []Order
 	for _, order := range orders {
-		if order.Configuration.Limit.Size > 0 && order.Configuration.Limit.Price > 0 {
+		if limit := order.Configuration.Limit(); limit != nil && limit.Size > 0 && limit.Price > 0 {
 			output = append(output, Order{
-				Size:  order.Configuration.Limit.Size,
-				Price: order.Configuration.Limit.Price,
+				Size:  limit.Size,
+				Price: limit.Price,
 			})
 		}
 	}
@@ -87,7 +90,7 @@ func (self *Coinbase) Orders(market string, side consts.OrderSide) ([]Order, err
 	return output, nil
 }
 
-func (self *Coinbase) Precision(market string) (*Precision, error) {
+func (self *Coinbase) Precision(market string, side consts.OrderSide) (*Precision, error) {
 	client, err := coinbase.New()
 	if err != nil {
 		return nil, err
@@ -102,7 +105,7 @@ func (self *Coinbase) Precision(market string) (*Precision, error) {
 	}, nil
 }
 
-func (self *Coinbase) Ticker(market string) (float64, error) {
+func (self *Coinbase) Ticker(market string, side consts.OrderSide) (float64, error) {
 	client, err := coinbase.New()
 	if err != nil {
 		return 0, err
@@ -114,6 +117,20 @@ func (self *Coinbase) Ticker(market string) (float64, error) {
 	return strconv.ParseFloat(product.Price, 64)
 }
 
+func (self *Coinbase) Validate(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
+	client, err := coinbase.New()
+	if err != nil {
+		return err
+	}
+	return client.PreviewOrder(market, side, func() float64 {
+		out, _ := size.Float64()
+		return out
+	}(), func() float64 {
+		out, _ := price.Float64()
+		return out
+	}(), expiresAt(days), postOnly)
+}
+
 func newCoinbase() Exchange {
 	return &Coinbase{
 		info: &info{
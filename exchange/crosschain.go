@@ -0,0 +1,320 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/svanas/ladder/api/oneinch"
+	"github.com/svanas/ladder/api/web3"
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/flag"
+	"github.com/svanas/ladder/precision"
+	"github.com/svanas/ladder/tokenlist"
+)
+
+// Settlement is the cross-chain status of one of your orders
+type Settlement struct {
+	Market   string
+	Side     string
+	Route    string // for example: Arbitrum → Ethereum
+	Id       string // the order hash
+	Status   string // for example: pending, executed, expired or refunded
+	Secrets  int    // the number of secrets that unlock the escrows of this order
+	Revealed int    // the number of secrets that have been revealed
+}
+
+// CrossChain is implemented by exchanges that settle your orders on another chain
+type CrossChain interface {
+	Settle(dry_run bool) ([]Settlement, error) // reveals the secrets of the orders whose escrows have been deployed
+}
+
+// returns the chain this symbol lives on: the source chain, unless the symbol is only known on the destination chain.
+// please use this for display purposes only, the side of your order tells which chain the asset (and the quote) live on.
+func locate(srcChainId, dstChainId int64, symbol string) int64 {
+	if pinned, err := flag.PinnedAddress(symbol); err == nil && pinned != "" {
+		symbol = pinned
+	}
+	for _, chainId := range []int64{srcChainId, dstChainId} {
+		if strings.EqualFold(symbol, web3.NativeCoin(chainId)) {
+			return chainId
+		}
+		if token, err := tokenlist.Find(chainId, symbol); err == nil && token != nil {
+			return chainId
+		}
+	}
+	return srcChainId
+}
+
+// returns the asset and the quote of a cross-chain market, and the chain each of them lives on
+func (self *OneInch) parseCrossChainMarket(srcChainId, dstChainId int64, market string, side consts.OrderSide) (*coin, int64, *coin, int64, error) { // --> (asset, asset chain, quote, quote chain, error)
+	symbols := strings.Split(market, "-")
+	if len(symbols) < 2 {
+		return nil, 0, nil, 0, fmt.Errorf("market %s does not exist", market)
+	}
+	// you spend on the source chain, and receive on the destination chain
+	var assetChain, quoteChain int64
+	switch side {
+	case consts.BUY:
+		assetChain, quoteChain = dstChainId, srcChainId
+	case consts.SELL:
+		assetChain, quoteChain = srcChainId, dstChainId
+	default:
+		return nil, 0, nil, 0, errors.New("a cross-chain market needs a side, because the side tells which chain your asset is on")
+	}
+	asset, err := self.parseSymbol(assetChain, symbols[0])
+	if err != nil {
+		return nil, 0, nil, 0, err
+	}
+	quote, err := self.parseSymbol(quoteChain, symbols[1])
+	if err != nil {
+		return nil, 0, nil, 0, err
+	}
+	return asset, assetChain, quote, quoteChain, nil
+}
+
+func (self *OneInch) crossChainPrecision(srcChainId, dstChainId int64, market string, side consts.OrderSide) (*Precision, error) {
+	asset, assetChain, quote, quoteChain, err := self.parseCrossChainMarket(srcChainId, dstChainId, market, side)
+	if err != nil {
+		return nil, err
+	}
+	assetDec, err := asset.getDecimals(self.coingecko, assetChain)
+	if err != nil {
+		return nil, err
+	}
+	quoteDec, err := quote.getDecimals(self.coingecko, quoteChain)
+	if err != nil {
+		return nil, err
+	}
+	return &Precision{
+		Price: quoteDec,
+		Size:  assetDec,
+	}, nil
+}
+
+func (self *OneInch) crossChainTicker(client *oneinch.Client, dstChainId int64, market string, side consts.OrderSide) (float64, error) {
+	asset, assetChain, quote, quoteChain, err := self.parseCrossChainMarket(client.ChainId, dstChainId, market, side)
+	if err != nil {
+		return 0, err
+	}
+
+	source, err := flag.GetPriceSource()
+	if err != nil {
+		return 0, err
+	}
+	switch source {
+	case flag.PRICE_SOURCE_UNISWAP:
+		return 0, errors.New("cannot read the price of a cross-chain market from Uniswap, please use another --" + consts.FLAG_PRICE_SOURCE)
+	case flag.PRICE_SOURCE_COINGECKO:
+		assetId := self.coinId(assetChain, asset)
+		quoteId := self.coinId(quoteChain, quote)
+		if assetId == "" || quoteId == "" {
+			return -1, nil
+		}
+		assetLast, err := self.coingecko.GetTicker(assetId)
+		if err != nil {
+			return 0, err
+		}
+		quoteLast, err := self.coingecko.GetTicker(quoteId)
+		if err != nil {
+			return 0, err
+		}
+		return assetLast / quoteLast, nil
+	}
+
+	assetDec, err := asset.getDecimals(self.coingecko, assetChain)
+	if err != nil {
+		return 0, err
+	}
+	quoteDec, err := quote.getDecimals(self.coingecko, quoteChain)
+	if err != nil {
+		return 0, err
+	}
+
+	// ask 1inch how much quote asset (on its chain) you would receive for one unit of the base asset (on its chain)
+	amount, _ := new(big.Float).SetFloat64(math.Pow(10, float64(assetDec))).Int(nil)
+	quoter := *client
+	quoter.ChainId = assetChain
+	received, err := quoter.GetCrossChainQuote(quoteChain, web3.Checksum(asset.address), web3.Checksum(quote.address), amount)
+	if err != nil {
+		return 0, err
+	}
+	out, _ := new(big.Float).Quo(new(big.Float).SetInt(received), new(big.Float).SetFloat64(math.Pow(10, float64(quoteDec)))).Float64()
+	return out, nil
+}
+
+// converts an order into scaled maker and taker amounts, and then calls place (to submit or validate the Fusion+ order)
+func (self *OneInch) crossChainOrder(client *oneinch.Client, dstChainId int64, market string, side consts.OrderSide, size, price big.Float, place func(dstChainId int64, market, side, makerAsset, takerAsset string, makerAmount, takerAmount big.Float) error) error {
+	asset, assetChain, quote, quoteChain, err := self.parseCrossChainMarket(client.ChainId, dstChainId, market, side)
+	if err != nil {
+		return err
+	}
+
+	// the native coin can only be received, you will need to wrap it before you can spend it
+	if (side == consts.SELL && asset.native) || (side == consts.BUY && quote.native) {
+		return fmt.Errorf("cannot spend %s, please wrap it first", web3.NativeCoin(client.ChainId))
+	}
+
+	assetDec, err := asset.getDecimals(self.coingecko, assetChain)
+	if err != nil {
+		return err
+	}
+	quoteDec, err := quote.getDecimals(self.coingecko, quoteChain)
+	if err != nil {
+		return err
+	}
+
+	// multiply an unscaled amount by these numbers to get the (scaled, non-floating) amount
+	assetMul := new(big.Float).SetFloat64(math.Pow(10, float64(assetDec)))
+	quoteMul := new(big.Float).SetFloat64(math.Pow(10, float64(quoteDec)))
+
+	assetAmount := new(big.Float).Mul(&size, assetMul)
+	quoteAmount := new(big.Float).Mul(new(big.Float).Mul(&size, &price), quoteMul)
+
+	switch side {
+	case consts.BUY:
+		return place(dstChainId, market, side.String(), web3.Checksum(quote.address), web3.Checksum(asset.address), *quoteAmount, *assetAmount)
+	case consts.SELL:
+		return place(dstChainId, market, side.String(), web3.Checksum(asset.address), web3.Checksum(quote.address), *assetAmount, *quoteAmount)
+	}
+	return fmt.Errorf("unknown order side %v", side)
+}
+
+// returns your cross-chain orders in this market (and on this side) that can still be filled
+func (self *OneInch) crossChainOrders(client *oneinch.Client, dstChainId int64, market string, side consts.OrderSide) ([]oneinch.CrossChainOrder, error) {
+	orders, err := client.GetCrossChainOrders(dstChainId)
+	if err != nil {
+		return nil, err
+	}
+	var out []oneinch.CrossChainOrder
+	for _, order := range orders {
+		if order.Market != market || order.Side != side.String() {
+			continue
+		}
+		status, err := order.GetStatus()
+		if err != nil {
+			return nil, err
+		}
+		if status.Open() {
+			out = append(out, order)
+		}
+	}
+	return out, nil
+}
+
+// converts your cross-chain orders into unscaled sizes and prices
+func (self *OneInch) crossChainOrderList(client *oneinch.Client, dstChainId int64, market string, side consts.OrderSide) ([]Order, error) {
+	asset, assetChain, quote, quoteChain, err := self.parseCrossChainMarket(client.ChainId, dstChainId, market, side)
+	if err != nil {
+		return nil, err
+	}
+
+	assetDec, err := asset.getDecimals(self.coingecko, assetChain)
+	if err != nil {
+		return nil, err
+	}
+	quoteDec, err := quote.getDecimals(self.coingecko, quoteChain)
+	if err != nil {
+		return nil, err
+	}
+
+	// divide a (scaled, non-floating) amount by these numbers to get the unscaled amount
+	assetDiv := new(big.Float).SetFloat64(math.Pow(10, float64(assetDec)))
+	quoteDiv := new(big.Float).SetFloat64(math.Pow(10, float64(quoteDec)))
+
+	orders, err := self.crossChainOrders(client, dstChainId, market, side)
+	if err != nil {
+		return nil, err
+	}
+	var result []Order
+	for _, order := range orders {
+		makerScaled, err := order.Data.GetMakerAmount()
+		if err != nil {
+			return nil, err
+		}
+		takerScaled, err := order.Data.GetTakerAmount()
+		if err != nil {
+			return nil, err
+		}
+		expiry, err := order.Data.GetExpiry()
+		if err != nil {
+			return nil, err
+		}
+		switch side {
+		case consts.BUY:
+			makerUnscaled, _ := new(big.Float).Quo(new(big.Float).SetInt(makerScaled), quoteDiv).Float64()
+			takerUnscaled, _ := new(big.Float).Quo(new(big.Float).SetInt(takerScaled), assetDiv).Float64()
+			result = append(result, Order{
+				Size:   takerUnscaled,
+				Price:  precision.Round((makerUnscaled / takerUnscaled), quoteDec),
+				Expiry: expiry,
+				Id:     order.OrderHash,
+			})
+		case consts.SELL:
+			makerUnscaled, _ := new(big.Float).Quo(new(big.Float).SetInt(makerScaled), assetDiv).Float64()
+			takerUnscaled, _ := new(big.Float).Quo(new(big.Float).SetInt(takerScaled), quoteDiv).Float64()
+			result = append(result, Order{
+				Size:   makerUnscaled,
+				Price:  precision.Round((takerUnscaled / makerUnscaled), quoteDec),
+				Expiry: expiry,
+				Id:     order.OrderHash,
+			})
+		}
+	}
+	return result, nil
+}
+
+// Settle reveals the secret of every fill whose escrows have been deployed on both chains, and forgets the secrets of
+// the orders that have been settled (or refunded)
+func (self *OneInch) Settle(dry_run bool) ([]Settlement, error) {
+	orders, err := oneinch.LoadCrossChainOrders()
+	if err != nil {
+		return nil, err
+	}
+	var out []Settlement
+	for _, order := range orders {
+		status, err := order.GetStatus()
+		if err != nil {
+			return out, err
+		}
+		revealed := len(order.Revealed)
+		if !status.Final() {
+			n, err := order.Reveal(dry_run)
+			if err != nil {
+				return out, err
+			}
+			// a dry run doesn't reveal anything, but we show you the secrets it would have revealed
+			if dry_run {
+				revealed += n
+			} else {
+				revealed = len(order.Revealed)
+			}
+		}
+		out = append(out, Settlement{
+			Market: order.Market,
+			Side:   order.Side,
+			Route: func() string {
+				name := func(chainId int64) string {
+					if chain, err := web3.GetChain(chainId); err == nil {
+						return chain.Name
+					}
+					return fmt.Sprintf("%d", chainId)
+				}
+				return fmt.Sprintf("%s → %s", name(order.SrcChainId), name(order.DstChainId))
+			}(),
+			Id:       order.OrderHash,
+			Status:   status.Status,
+			Secrets:  len(order.Secrets),
+			Revealed: revealed,
+		})
+		if status.Final() && !dry_run {
+			if err := order.Forget(); err != nil {
+				return out, err
+			}
+		}
+	}
+	return out, nil
+}
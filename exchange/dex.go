@@ -4,14 +4,18 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/svanas/ladder/api/coingecko"
 	"github.com/svanas/ladder/api/web3"
+	"github.com/svanas/ladder/flag"
+	"github.com/svanas/ladder/tokenlist"
 )
 
 // abstract base struct for DEXes
 type dex struct {
 	*info
 	coingecko *coingecko.Client
+	chosen    map[string]*coin // the token you chose, per ambiguous symbol
 }
 
 func (dex *dex) formatMarket(asset, quote string) (string, error) {
@@ -19,11 +23,16 @@ func (dex *dex) formatMarket(asset, quote string) (string, error) {
 }
 
 type coin struct {
-	id      string // coingecko coin id
-	address string // on-chain token address
+	id      string           // coingecko coin id
+	address string           // on-chain token address
+	native  bool             // true if this is the native coin, traded as its wrapped token
+	token   *tokenlist.Token // the token on one of the token lists, if any
 }
 
 func (coin *coin) getDecimals(coingecko *coingecko.Client, chainId int64) (int, error) {
+	if coin.token != nil {
+		return coin.token.Decimals, nil
+	}
 	if coin.id == "" {
 		client, err := web3.New(chainId)
 		if err != nil {
@@ -35,34 +44,88 @@ func (coin *coin) getDecimals(coingecko *coingecko.Client, chainId int64) (int,
 	}
 }
 
+func isAddress(symbol string) bool {
+	return len(symbol) == 42 && strings.HasPrefix(strings.ToLower(symbol), "0x")
+}
+
+func (dex *dex) parseSymbol(chainId int64, symbol string) (*coin, error) {
+	// the address you pinned with --asset-address or --quote-address takes precedence over the symbol
+	pinned, err := flag.PinnedAddress(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if pinned != "" {
+		symbol = pinned
+	}
+	// the native coin (for example: ETH) is traded as its wrapped token (for example: WETH)
+	if strings.EqualFold(symbol, web3.NativeCoin(chainId)) {
+		wrapped, err := web3.WrappedNativeCoin(chainId)
+		if err != nil {
+			return nil, err
+		}
+		coin, err := dex.parseSymbol(chainId, wrapped)
+		if err != nil {
+			return nil, err
+		}
+		coin.native = true
+		return coin, nil
+	}
+	// a symbol can refer to more than one token, an address cannot
+	if !isAddress(symbol) {
+		return dex.resolve(chainId, symbol)
+	}
+	// look for the token on the (offline) token lists first, and then on coingecko
+	token, err := tokenlist.Find(chainId, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if token != nil {
+		return &coin{address: token.Address, token: token}, nil
+	}
+	id, _, _, err := dex.coingecko.GetCoin(symbol, chainId)
+	if err != nil {
+		return &coin{address: symbol}, nil
+	}
+	return &coin{id: id, address: symbol}, nil
+}
+
 func (dex *dex) parseMarket(chainId int64, market string) (*coin, *coin, error) { // --> (asset, quote, error)
 	symbols := strings.Split(market, "-")
 	if len(symbols) > 1 {
-		assetId, _, assetAddr, err := dex.coingecko.GetCoin(symbols[0], chainId)
+		asset, err := dex.parseSymbol(chainId, symbols[0])
 		if err != nil {
-			if len(symbols[0]) == 42 && strings.HasPrefix(strings.ToLower(symbols[0]), "0x") {
-				assetAddr = symbols[0]
-			} else {
-				return nil, nil, err
-			}
+			return nil, nil, err
 		}
-		quoteId, _, quoteAddr, err := dex.coingecko.GetCoin(symbols[1], chainId)
+		quote, err := dex.parseSymbol(chainId, symbols[1])
 		if err != nil {
-			if len(symbols[1]) == 42 && strings.HasPrefix(strings.ToLower(symbols[1]), "0x") {
-				quoteAddr = symbols[1]
-			} else {
-				return nil, nil, err
-			}
+			return nil, nil, err
 		}
-		return &coin{assetId, assetAddr}, &coin{quoteId, quoteAddr}, nil
+		return asset, quote, nil
 	}
 	return nil, nil, fmt.Errorf("market %s does not exist", market)
 }
 
 func (dex *dex) formatSymbol(chainId int64, symbol string) (string, error) {
+	pinned, err := flag.PinnedAddress(symbol)
+	if err != nil {
+		return "", err
+	}
+	if pinned != "" {
+		symbol = pinned
+	}
+	if strings.EqualFold(symbol, web3.NativeCoin(chainId)) {
+		return strings.ToUpper(symbol), nil
+	}
+	token, err := tokenlist.Find(chainId, symbol)
+	if err != nil {
+		return "", err
+	}
+	if token != nil {
+		return strings.ToUpper(token.Symbol), nil
+	}
 	_, sym, _, err := dex.coingecko.GetCoin(symbol, chainId)
 	if err != nil {
-		if len(symbol) == 42 && strings.HasPrefix(strings.ToLower(symbol), "0x") {
+		if isAddress(symbol) {
 			client, err := web3.New(chainId)
 			if err != nil {
 				return "", err
@@ -77,11 +140,37 @@ func (dex *dex) formatSymbol(chainId int64, symbol string) (string, error) {
 	return strings.ToUpper(sym), nil
 }
 
+// reads the decimals and the symbol of the coins that aren't on a token list (nor on coingecko) in one round-trip
+func prefetch(chainId int64, coins ...*coin) error {
+	var calls []ethereum.CallMsg
+	for _, coin := range coins {
+		if coin.id == "" && coin.token == nil {
+			tokenCalls, err := web3.TokenCalls(coin.address, "", "")
+			if err != nil {
+				return err
+			}
+			calls = append(calls, tokenCalls...)
+		}
+	}
+	if len(calls) == 0 {
+		return nil
+	}
+	client, err := web3.New(chainId)
+	if err != nil {
+		return err
+	}
+	client.Prefetch(calls)
+	return nil
+}
+
 func (dex *dex) precision(chainId int64, market string) (*Precision, error) {
 	asset, quote, err := dex.parseMarket(chainId, market)
 	if err != nil {
 		return nil, err
 	}
+	if err := prefetch(chainId, asset, quote); err != nil {
+		return nil, err
+	}
 	assetDec, err := asset.getDecimals(dex.coingecko, chainId)
 	if err != nil {
 		return nil, err
@@ -101,25 +190,31 @@ func (dex *dex) ticker(chainId int64, market string) (float64, error) {
 	if err != nil {
 		return 0, err
 	}
-	assetLast, err := func() (float64, error) {
-		if asset.id == "" {
-			return -1, nil
-		} else {
-			return dex.coingecko.GetTicker(asset.id)
-		}
-	}()
+	// we can only get a price for tokens that coingecko knows about
+	assetId := dex.coinId(chainId, asset)
+	quoteId := dex.coinId(chainId, quote)
+	if assetId == "" || quoteId == "" {
+		return -1, nil
+	}
+	assetLast, err := dex.coingecko.GetTicker(assetId)
 	if err != nil {
 		return 0, err
 	}
-	quoteLast, err := func() (float64, error) {
-		if quote.id == "" {
-			return -1, nil
-		} else {
-			return dex.coingecko.GetTicker(quote.id)
-		}
-	}()
+	quoteLast, err := dex.coingecko.GetTicker(quoteId)
 	if err != nil {
 		return 0, err
 	}
 	return assetLast / quoteLast, nil
 }
+
+// returns the coingecko coin id, or an empty string if coingecko doesn't know about this coin
+func (dex *dex) coinId(chainId int64, coin *coin) string {
+	if coin.id != "" {
+		return coin.id
+	}
+	id, _, _, err := dex.coingecko.GetCoin(coin.address, chainId)
+	if err != nil {
+		return ""
+	}
+	return id
+}
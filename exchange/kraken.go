@@ -2,6 +2,7 @@
 package exchange
 
 import (
+	"fmt"
 	"math/big"
 	"strings"
 
@@ -47,7 +48,7 @@ func (self *Kraken) Info() *info {
 	return self.info
 }
 
-func (_ *Kraken) Order(market string, side consts.OrderSide, size, price big.Float, days int) error {
+func (_ *Kraken) Order(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
 	client, err := kraken.ReadWrite()
 	if err != nil {
 		return err
@@ -58,7 +59,10 @@ func (_ *Kraken) Order(market string, side consts.OrderSide, size, price big.Flo
 	}(), func() float64 {
 		out, _ := price.Float64()
 		return out
-	}()); err != nil {
+	}(), expiresAt(days), postOnly); err != nil {
+		if kraken.IsPostOnlyRejected(err) {
+			return fmt.Errorf("%w: %v", ErrPostOnly, err)
+		}
 		return err
 	}
 	return nil
@@ -88,7 +92,7 @@ func (_ *Kraken) Orders(market string, side consts.OrderSide) ([]Order, error) {
 	return output, nil
 }
 
-func (_ *Kraken) Precision(market string) (*Precision, error) {
+func (_ *Kraken) Precision(market string, side consts.OrderSide) (*Precision, error) {
 	client, err := kraken.ReadOnly()
 	if err != nil {
 		return nil, err
@@ -103,7 +107,7 @@ func (_ *Kraken) Precision(market string) (*Precision, error) {
 	}, nil
 }
 
-func (_ *Kraken) Ticker(market string) (float64, error) {
+func (_ *Kraken) Ticker(market string, side consts.OrderSide) (float64, error) {
 	client, err := kraken.ReadOnly()
 	if err != nil {
 		return 0, err
@@ -111,6 +115,20 @@ func (_ *Kraken) Ticker(market string) (float64, error) {
 	return client.Ticker(market)
 }
 
+func (_ *Kraken) Validate(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
+	client, err := kraken.ReadWrite()
+	if err != nil {
+		return err
+	}
+	return client.ValidateOrder(market, side, func() float64 {
+		out, _ := size.Float64()
+		return out
+	}(), func() float64 {
+		out, _ := price.Float64()
+		return out
+	}(), expiresAt(days), postOnly)
+}
+
 func newKraken() Exchange {
 	return &Kraken{
 		info: &info{
@@ -2,11 +2,16 @@
 package exchange
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
+	"github.com/svanas/ladder/api/oneinch"
+	"github.com/svanas/ladder/api/web3"
 	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/journal"
 )
 
 type info struct {
@@ -19,8 +24,16 @@ func (self *info) equals(name string) bool {
 }
 
 type Order struct {
-	Size  float64
-	Price float64
+	Size   float64
+	Price  float64
+	Filled float64   // the portion of the size that has been filled (between 0 and 1), if known
+	Expiry time.Time // the time the order will expire, or zero if unknown or good-til-cancelled
+	Id     string    // the order id (or hash), if known
+}
+
+// returns the size that hasn't been filled yet
+func (order *Order) Remaining() float64 {
+	return order.Size * (1 - order.Filled)
 }
 
 func (order *Order) BigSize() big.Float {
@@ -31,6 +44,14 @@ func (order *Order) BigPrice() big.Float {
 	return *new(big.Float).SetFloat64(order.Price)
 }
 
+// returns the time an order placed today will expire, or zero if the order is good-til-cancelled
+func expiresAt(days int) time.Time {
+	if days > 0 {
+		return time.Now().Add(time.Duration(days) * 24 * time.Hour)
+	}
+	return time.Time{}
+}
+
 type Precision struct {
 	Price int
 	Size  int
@@ -41,10 +62,78 @@ type Exchange interface {
 	FormatSymbol(asset string) (string, error)
 	FormatMarket(asset, quote string) (string, error)
 	Info() *info
-	Order(market string, side consts.OrderSide, size, price big.Float, days int) error
+	Order(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error
 	Orders(market string, side consts.OrderSide) ([]Order, error)
-	Precision(market string) (*Precision, error)
-	Ticker(market string) (float64, error)
+	Precision(market string, side consts.OrderSide) (*Precision, error)
+	Ticker(market string, side consts.OrderSide) (float64, error)
+	Validate(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error
+}
+
+// ErrPostOnly is returned when a post-only order is rejected, because it would have traded as a taker
+var ErrPostOnly = errors.New("post-only order rejected")
+
+// ErrSafeTransaction is returned when your smart-contract wallet (for example: a Safe) needs to send a transaction before you can continue
+var ErrSafeTransaction = errors.New("please have your smart-contract wallet send this transaction first")
+
+// ErrCrossChainDays is returned when you include --days with a cross-chain order, because 1inch expires these orders
+var ErrCrossChainDays = errors.New("cross-chain orders do not support --" + consts.FLAG_DAYS + ", because 1inch expires them")
+
+// ErrCrossChainPermit is returned when you include --permit with a cross-chain order
+var ErrCrossChainPermit = errors.New("cross-chain orders do not support --" + consts.FLAG_PERMIT + ", please approve your asset on-chain")
+
+// Expirer is implemented by exchanges that do not support good-til-date orders natively.
+// the expiry of every order is kept in a local journal, and Expire cancels the orders that have expired.
+type Expirer interface {
+	Expire(dry_run bool) ([]journal.Entry, error) // --> (expired orders, error)
+}
+
+// OnChain is implemented by exchanges that cancel your orders with an on-chain transaction
+type OnChain interface {
+	CancelCost(market string, side consts.OrderSide) (float64, string, error) // --> (max cost in native coin, native coin, error)
+}
+
+// Approver is implemented by exchanges that need an on-chain allowance before they can spend your tokens
+type Approver interface {
+	Allowance(asset string) (*big.Int, error)                               // --> (current allowance, scaled)
+	Committed(asset string) (*big.Int, error)                               // --> (what your open orders still need to spend, scaled)
+	Scale(asset string, amount float64) (*big.Int, error)                   // --> (amount scaled by the decimals of asset, rounded up)
+	Approve(asset string, amount *big.Int, dry_run bool) (*Approval, error) // sets the allowance to (exactly) amount
+}
+
+// Permitter is implemented by exchanges that accept a signed permit in lieu of an on-chain allowance
+type Permitter interface {
+	Permit(asset string, amount float64, days int) (*oneinch.Permit, error) // signs a permit for amount, or returns nil if you didn't ask for one
+	OrderWithPermit(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool, permit *oneinch.Permit) error
+	ValidateWithPermit(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool, permit *oneinch.Permit) error
+}
+
+// Planner is implemented by exchanges that can export unsigned orders, to be signed on an air-gapped machine
+type Planner interface {
+	Plan(market string, side consts.OrderSide, orders []Order, days int, path string) error
+}
+
+// Holder is implemented by exchanges that can read the balances and the open orders of any address
+type Holder interface {
+	Holdings(market, address string) (*Holdings, error)
+}
+
+type Approval struct {
+	Asset  string          // the token that gets approved
+	Amount float64         // the new allowance
+	Reset  bool            // true if the allowance gets reset to zero first
+	Cost   float64         // the maximum gas cost (in native coin)
+	Coin   string          // the native coin
+	TxHash string          // the transaction hash, or empty if nothing was broadcast
+	Batch  *web3.SafeBatch // the transaction(s) your smart-contract wallet will need to send, if you are trading on behalf of one
+}
+
+type Holdings struct {
+	Native float64 // the balance of the native coin
+	Coin   string  // the native coin
+	Asset  float64 // the balance of the base asset
+	Quote  float64 // the balance of the quote asset
+	Buy    []Order // the open buy orders
+	Sell   []Order // the open sell orders
 }
 
 var exchanges []Exchange
@@ -55,6 +144,7 @@ func init() {
 	exchanges = append(exchanges, newBinance())
 	exchanges = append(exchanges, newOneInch())
 	exchanges = append(exchanges, newKraken())
+	exchanges = append(exchanges, newPaper())
 }
 
 func FindByName(name string) (Exchange, error) {
@@ -2,15 +2,20 @@
 package exchange
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/svanas/ladder/api/coingecko"
 	"github.com/svanas/ladder/api/oneinch"
 	"github.com/svanas/ladder/api/web3"
 	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/flag"
 	"github.com/svanas/ladder/precision"
 )
 
@@ -18,35 +23,223 @@ type OneInch struct {
 	*dex
 }
 
+// IsOneInch returns true if this exchange is 1inch, otherwise false
+func IsOneInch(exc Exchange) bool {
+	_, ok := exc.(*OneInch)
+	return ok
+}
+
+// returns the (scaled, non-floating) amount of an unscaled amount. rounds up, so that an allowance never falls short.
+func scale(amount float64, dec int) *big.Int {
+	// the shortest decimal representation of a float64 is what the user meant, for example 0.3 (not 0.299999999999999988898)
+	unscaled, _ := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	scaled := new(big.Rat).Mul(unscaled, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(dec)), nil)))
+	out, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		out.Add(out, big.NewInt(1))
+	}
+	return out
+}
+
+func (self *OneInch) Allowance(asset string) (*big.Int, error) {
+	client, err := oneinch.ReadWrite()
+	if err != nil {
+		return nil, err
+	}
+	token, err := self.parseSymbol(client.ChainId, asset)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetAllowance(token.address)
+}
+
+func (self *OneInch) Committed(asset string) (*big.Int, error) {
+	client, err := oneinch.ReadWrite()
+	if err != nil {
+		return nil, err
+	}
+	token, err := self.parseSymbol(client.ChainId, asset)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetCommitted(token.address)
+}
+
+func (self *OneInch) Scale(asset string, amount float64) (*big.Int, error) {
+	client, err := oneinch.ReadWrite()
+	if err != nil {
+		return nil, err
+	}
+	token, err := self.parseSymbol(client.ChainId, asset)
+	if err != nil {
+		return nil, err
+	}
+	dec, err := token.getDecimals(self.coingecko, client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+	return scale(amount, dec), nil
+}
+
+func (self *OneInch) Approve(asset string, amount *big.Int, dry_run bool) (*Approval, error) {
+	client, err := oneinch.ReadWrite()
+	if err != nil {
+		return nil, err
+	}
+	token, err := self.parseSymbol(client.ChainId, asset)
+	if err != nil {
+		return nil, err
+	}
+	dec, err := token.getDecimals(self.coingecko, client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+	reset := false
+	fee, err := client.EstimateApprove(token.address, amount, false)
+	if err != nil {
+		// some tokens (for example: USDT) won't change a non-zero allowance, unless you reset it to zero first
+		allowance, err2 := client.GetAllowance(token.address)
+		if err2 != nil || allowance.Sign() == 0 || amount.Sign() == 0 {
+			return nil, err
+		}
+		if fee, err = client.EstimateApprove(token.address, amount, true); err != nil {
+			return nil, err
+		}
+		reset = true
+	}
+	cost, _ := new(big.Float).Quo(new(big.Float).SetInt(fee.Cost()), big.NewFloat(1e18)).Float64()
+	unscaled, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), new(big.Float).SetFloat64(math.Pow(10, float64(dec)))).Float64()
+	approval := &Approval{
+		Asset:  asset,
+		Amount: unscaled,
+		Reset:  reset,
+		Cost:   cost,
+		Coin:   web3.NativeCoin(client.ChainId),
+	}
+	if dry_run {
+		return approval, nil
+	}
+	// your smart-contract wallet (for example: a Safe) will need to send the transaction(s) for you
+	if client.HasMaker() {
+		approval.Batch, err = client.SafeApprove(web3.Checksum(token.address), amount, reset)
+		return approval, err
+	}
+	tx, err := client.Approve(web3.Checksum(token.address), amount, reset)
+	if tx != nil {
+		approval.TxHash = tx.Hash().Hex()
+	}
+	return approval, err
+}
+
+// PrintSafeBatch prints the transaction(s) your smart-contract wallet will need to send, so that you can import them in the Safe{Wallet} Transaction Builder
+func PrintSafeBatch(batch *web3.SafeBatch) error {
+	data, err := json.MarshalIndent(batch, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println("Please import this batch in the Safe{Wallet} Transaction Builder, and have your Safe send it:")
+	fmt.Println(string(data))
+	return nil
+}
+
 func (self *OneInch) Cancel(market string, side consts.OrderSide) error {
-	orders, err := self.Orders(market, side)
+	client, err := oneinch.ReadWrite()
+	if err != nil {
+		return err
+	}
+	orders, all, err := self.cancellable(client, market, side)
 	if err != nil {
 		return err
 	}
 	if len(orders) == 0 {
 		return nil
 	}
-	symbols := strings.Split(market, "-")
-	if len(symbols) < 2 {
-		return fmt.Errorf("market %s does not exist", market)
+	// your smart-contract wallet (for example: a Safe) will need to send the transaction for you
+	if client.HasMaker() {
+		batch, err := client.SafeCancel(orders, all)
+		if err != nil {
+			return err
+		}
+		if err := PrintSafeBatch(batch); err != nil {
+			return err
+		}
+		return ErrSafeTransaction
 	}
-	asset, quote, err := func() (string, string, error) {
-		switch side {
-		case consts.BUY:
-			return symbols[1], symbols[0], nil
-		case consts.SELL:
-			return symbols[0], symbols[1], nil
+	tx, err := client.CancelOrders(orders, all)
+	if tx != nil {
+		fmt.Printf("Cancelled %d order(s) in transaction %s\n", len(orders), tx.Hash().Hex())
+	}
+	return err
+}
+
+// CancelCost returns the maximum amount of native coin it will cost to cancel your orders
+func (self *OneInch) CancelCost(market string, side consts.OrderSide) (float64, string, error) { // --> (cost, native coin, error)
+	client, err := oneinch.ReadWrite()
+	if err != nil {
+		return 0, "", err
+	}
+	orders, all, err := self.cancellable(client, market, side)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(orders) == 0 {
+		return 0, web3.NativeCoin(client.ChainId), nil
+	}
+	fee, err := client.EstimateCancel(orders, all)
+	if err != nil {
+		return 0, "", err
+	}
+	cost, _ := new(big.Float).Quo(new(big.Float).SetInt(fee.Cost()), big.NewFloat(1e18)).Float64()
+	return cost, web3.NativeCoin(client.ChainId), nil
+}
+
+// returns the orders that match this market and side, and whether you want to invalidate every order you have by
+// increasing your epoch (which is cheaper, but also invalidates your orders in other markets)
+func (self *OneInch) cancellable(client *oneinch.Client, market string, side consts.OrderSide) ([]oneinch.Order, bool, error) { // --> (orders, all, error)
+	// your cross-chain orders get cancelled one by one, because they aren't in your epoch
+	dstChainId, err := flag.DstChainId()
+	if err != nil {
+		return nil, false, err
+	}
+	if dstChainId != 0 {
+		orders, err := self.crossChainOrders(client, dstChainId, market, side)
+		if err != nil {
+			return nil, false, err
 		}
-		return "", "", fmt.Errorf("unknown order side %v", side)
-	}()
+		var out []oneinch.Order
+		for _, order := range orders {
+			out = append(out, order.Order)
+		}
+		return out, false, nil
+	}
+
+	orders, err := client.GetOrders()
 	if err != nil {
-		return err
+		return nil, false, err
 	}
-	client, err := oneinch.ReadOnly()
+	matches, err := self.matches(client, orders, market, side)
 	if err != nil {
-		return err
+		return nil, false, err
+	}
+	return matches, flag.Epoch(), nil
+}
+
+// returns the orders that match this market and side
+func (self *OneInch) matches(client *oneinch.Client, orders []oneinch.Order, market string, side consts.OrderSide) ([]oneinch.Order, error) {
+	asset, quote, err := self.parseMarket(client.ChainId, market)
+	if err != nil {
+		return nil, err
+	}
+	var result []oneinch.Order
+	for _, order := range orders {
+		if side == consts.BUY && strings.EqualFold(order.Data.MakerAsset, quote.address) && strings.EqualFold(order.Data.TakerAsset, asset.address) {
+			result = append(result, order)
+		}
+		if side == consts.SELL && strings.EqualFold(order.Data.MakerAsset, asset.address) && strings.EqualFold(order.Data.TakerAsset, quote.address) {
+			result = append(result, order)
+		}
 	}
-	return fmt.Errorf("please cancel your orders on https://1inch.com/pro?mode=limit&pair=%d:%s-%s", client.ChainId, asset, quote)
+	return result, nil
 }
 
 func (self *OneInch) FormatSymbol(asset string) (string, error) {
@@ -54,6 +247,13 @@ func (self *OneInch) FormatSymbol(asset string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	dstChainId, err := flag.DstChainId()
+	if err != nil {
+		return "", err
+	}
+	if dstChainId != 0 {
+		return self.formatSymbol(locate(client.ChainId, dstChainId, asset), asset)
+	}
 	return self.formatSymbol(client.ChainId, asset)
 }
 
@@ -61,40 +261,122 @@ func (self *OneInch) FormatMarket(asset, quote string) (string, error) {
 	return self.formatMarket(asset, quote)
 }
 
+// Holdings returns the balances and the open orders of an address (for example: an account in your HD wallet)
+func (self *OneInch) Holdings(market, address string) (*Holdings, error) {
+	client, err := oneinch.ReadAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, quote, err := self.parseMarket(client.ChainId, market)
+	if err != nil {
+		return nil, err
+	}
+
+	assetDec, err := asset.getDecimals(self.coingecko, client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+	quoteDec, err := quote.getDecimals(self.coingecko, client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Holdings{Coin: web3.NativeCoin(client.ChainId)}
+
+	web3, err := web3.New(client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	// divides a (scaled, non-floating) amount by 10^dec
+	unscale := func(amount *big.Int, dec int) float64 {
+		out, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), new(big.Float).SetFloat64(math.Pow(10, float64(dec)))).Float64()
+		return out
+	}
+
+	native, err := web3.GetNativeBalance(address)
+	if err != nil {
+		return nil, err
+	}
+	out.Native = unscale(native, 18)
+
+	balance, err := web3.GetBalance(asset.address, address)
+	if err != nil {
+		return nil, err
+	}
+	out.Asset = unscale(balance, assetDec)
+
+	if balance, err = web3.GetBalance(quote.address, address); err != nil {
+		return nil, err
+	}
+	out.Quote = unscale(balance, quoteDec)
+
+	orders, err := client.GetOrders()
+	if err != nil {
+		return nil, err
+	}
+	if out.Buy, err = self.orders(client, orders, market, consts.BUY); err != nil {
+		return nil, err
+	}
+	if out.Sell, err = self.orders(client, orders, market, consts.SELL); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (self *OneInch) Info() *info {
 	return self.info
 }
 
-func (self *OneInch) epoch() (*big.Int, error) {
+func (self *OneInch) Order(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
+	return self.OrderWithPermit(market, side, size, price, days, postOnly, nil)
+}
+
+func (self *OneInch) OrderWithPermit(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool, permit *oneinch.Permit) error {
 	client, err := oneinch.ReadWrite()
 	if err != nil {
-		return big.NewInt(0), err
+		return err
 	}
-
-	nonce, err := client.GetEpoch()
+	dstChainId, err := flag.DstChainId()
 	if err != nil {
-		return big.NewInt(0), err
+		return err
 	}
-
-	return nonce, nil
+	if dstChainId != 0 {
+		if days > 0 {
+			return ErrCrossChainDays
+		}
+		if permit != nil {
+			return ErrCrossChainPermit
+		}
+		return self.crossChainOrder(client, dstChainId, market, side, size, price, client.PlaceCrossChainOrder)
+	}
+	return self.order(client, market, side, size, price, days, permit, client.PlaceOrder)
 }
 
-func (self *OneInch) Order(market string, side consts.OrderSide, size, price big.Float, days int) error {
-	epoch, err := self.epoch()
+// converts an order into scaled maker and taker amounts, and then calls place (to post, validate or plan the order)
+func (self *OneInch) order(client *oneinch.Client, market string, side consts.OrderSide, size, price big.Float, days int, permit *oneinch.Permit, place func(makerAsset, takerAsset string, makerAmount, takerAmount big.Float, nonce big.Int, days int, permit *oneinch.Permit, unwrap bool) error) error {
+	asset, quote, err := self.parseMarket(client.ChainId, market)
 	if err != nil {
 		return err
 	}
 
-	client, err := oneinch.ReadWrite()
-	if err != nil {
+	// read everything we need from the chain in one round-trip, the next order reads it from the cache
+	if err := client.Prefetch(asset.address, quote.address); err != nil {
 		return err
 	}
 
-	asset, quote, err := self.parseMarket(client.ChainId, market)
+	epoch, err := client.GetEpoch()
 	if err != nil {
 		return err
 	}
 
+	// the native coin can only be received, you will need to wrap it before you can spend it
+	if (side == consts.SELL && asset.native) || (side == consts.BUY && quote.native) {
+		return fmt.Errorf("cannot spend %s, please wrap it first", web3.NativeCoin(client.ChainId))
+	}
+
 	assetDec, err := asset.getDecimals(self.coingecko, client.ChainId)
 	if err != nil {
 		return err
@@ -116,9 +398,9 @@ func (self *OneInch) Order(market string, side consts.OrderSide, size, price big
 		err = func() error {
 			switch side {
 			case consts.BUY:
-				return client.PlaceOrder(web3.Checksum(quote.address), web3.Checksum(asset.address), *quoteAmount, *assetAmount, *epoch, days)
+				return place(web3.Checksum(quote.address), web3.Checksum(asset.address), *quoteAmount, *assetAmount, *epoch, days, permit, asset.native)
 			case consts.SELL:
-				return client.PlaceOrder(web3.Checksum(asset.address), web3.Checksum(quote.address), *assetAmount, *quoteAmount, *epoch, days)
+				return place(web3.Checksum(asset.address), web3.Checksum(quote.address), *assetAmount, *quoteAmount, *epoch, days, permit, quote.native)
 			}
 			return fmt.Errorf("unknown order side %v", side)
 		}()
@@ -133,7 +415,22 @@ func (self *OneInch) Orders(market string, side consts.OrderSide) ([]Order, erro
 	if err != nil {
 		return nil, err
 	}
+	dstChainId, err := flag.DstChainId()
+	if err != nil {
+		return nil, err
+	}
+	if dstChainId != 0 {
+		return self.crossChainOrderList(client, dstChainId, market, side)
+	}
+	orders, err := client.GetOrders()
+	if err != nil {
+		return nil, err
+	}
+	return self.orders(client, orders, market, side)
+}
 
+// returns the orders (out of all your orders) that match this market and side
+func (self *OneInch) orders(client *oneinch.Client, orders []oneinch.Order, market string, side consts.OrderSide) ([]Order, error) {
 	asset, quote, err := self.parseMarket(client.ChainId, market)
 	if err != nil {
 		return nil, err
@@ -152,12 +449,12 @@ func (self *OneInch) Orders(market string, side consts.OrderSide) ([]Order, erro
 	assetDiv := new(big.Float).SetFloat64(math.Pow(10, float64(assetDec)))
 	quoteDiv := new(big.Float).SetFloat64(math.Pow(10, float64(quoteDec)))
 
-	orders, err := client.GetOrders()
+	matches, err := self.matches(client, orders, market, side)
 	if err != nil {
 		return nil, err
 	}
 	var result []Order
-	for _, order := range orders {
+	for _, order := range matches {
 		makerScaled, err := order.Data.GetMakerAmount()
 		if err != nil {
 			return nil, err
@@ -166,40 +463,196 @@ func (self *OneInch) Orders(market string, side consts.OrderSide) ([]Order, erro
 		if err != nil {
 			return nil, err
 		}
-		if side == consts.BUY && strings.EqualFold(order.Data.MakerAsset, quote.address) && strings.EqualFold(order.Data.TakerAsset, asset.address) {
+		remaining, err := order.GetRemainingAmount()
+		if err != nil {
+			return nil, err
+		}
+		expiry, err := order.Data.GetExpiry()
+		if err != nil {
+			return nil, err
+		}
+		// the portion of the making amount that has been filled
+		filled := func() float64 {
+			if makerScaled.Sign() == 0 {
+				return 0
+			}
+			out, _ := new(big.Float).Quo(new(big.Float).SetInt(new(big.Int).Sub(makerScaled, remaining)), new(big.Float).SetInt(makerScaled)).Float64()
+			return out
+		}()
+		switch side {
+		case consts.BUY:
 			makerUnscaled, _ := new(big.Float).Quo(new(big.Float).SetInt(makerScaled), quoteDiv).Float64()
 			takerUnscaled, _ := new(big.Float).Quo(new(big.Float).SetInt(takerScaled), assetDiv).Float64()
 			result = append(result, Order{
-				Size:  takerUnscaled,
-				Price: precision.Round((makerUnscaled / takerUnscaled), quoteDec),
+				Size:   takerUnscaled,
+				Price:  precision.Round((makerUnscaled / takerUnscaled), quoteDec),
+				Filled: filled,
+				Expiry: expiry,
+				Id:     order.OrderHash,
 			})
-		}
-		if side == consts.SELL && strings.EqualFold(order.Data.MakerAsset, asset.address) && strings.EqualFold(order.Data.TakerAsset, quote.address) {
+		case consts.SELL:
 			makerUnscaled, _ := new(big.Float).Quo(new(big.Float).SetInt(makerScaled), assetDiv).Float64()
 			takerUnscaled, _ := new(big.Float).Quo(new(big.Float).SetInt(takerScaled), quoteDiv).Float64()
 			result = append(result, Order{
-				Size:  makerUnscaled,
-				Price: precision.Round((takerUnscaled / makerUnscaled), quoteDec),
+				Size:   makerUnscaled,
+				Price:  precision.Round((takerUnscaled / makerUnscaled), quoteDec),
+				Filled: filled,
+				Expiry: expiry,
+				Id:     order.OrderHash,
 			})
 		}
 	}
 	return result, nil
 }
 
-func (self *OneInch) Precision(market string) (*Precision, error) {
+func (self *OneInch) Permit(asset string, amount float64, days int) (*oneinch.Permit, error) {
+	if dstChainId, err := flag.DstChainId(); err != nil {
+		return nil, err
+	} else if dstChainId != 0 {
+		return nil, ErrCrossChainPermit
+	}
+	client, err := oneinch.ReadWrite()
+	if err != nil {
+		return nil, err
+	}
+	token, err := self.parseSymbol(client.ChainId, asset)
+	if err != nil {
+		return nil, err
+	}
+	dec, err := token.getDecimals(self.coingecko, client.ChainId)
+	if err != nil {
+		return nil, err
+	}
+	deadline := func() time.Duration {
+		if days > 0 {
+			return time.Duration(days) * 24 * time.Hour
+		}
+		return consts.THREE_YEARS
+	}()
+	return client.SignPermit(web3.Checksum(token.address), scale(amount, dec), time.Now().Add(deadline).Unix())
+}
+
+func (self *OneInch) Plan(market string, side consts.OrderSide, orders []Order, days int, path string) error {
+	if dstChainId, err := flag.DstChainId(); err != nil {
+		return err
+	} else if dstChainId != 0 {
+		return errors.New("cannot plan cross-chain orders, because their secrets cannot leave this machine")
+	}
+	client, err := oneinch.ReadMaker()
+	if err != nil {
+		return err
+	}
+	plan, err := client.NewPlan()
+	if err != nil {
+		return err
+	}
+	for _, order := range orders {
+		if err := self.order(client, market, side, order.BigSize(), order.BigPrice(), days, nil, func(makerAsset, takerAsset string, makerAmount, takerAmount big.Float, nonce big.Int, days int, permit *oneinch.Permit, unwrap bool) error {
+			planned, err := client.PlanOrder(makerAsset, takerAsset, makerAmount, takerAmount, nonce, days, permit, unwrap)
+			if err != nil {
+				return err
+			}
+			plan.Add(planned)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return plan.Save(path)
+}
+
+func (self *OneInch) Precision(market string, side consts.OrderSide) (*Precision, error) {
 	client, err := oneinch.ReadOnly()
 	if err != nil {
 		return nil, err
 	}
+	dstChainId, err := flag.DstChainId()
+	if err != nil {
+		return nil, err
+	}
+	if dstChainId != 0 {
+		return self.crossChainPrecision(client.ChainId, dstChainId, market, side)
+	}
 	return self.precision(client.ChainId, market)
 }
 
-func (self *OneInch) Ticker(market string) (float64, error) {
+func (self *OneInch) Ticker(market string, side consts.OrderSide) (float64, error) {
 	client, err := oneinch.ReadOnly()
 	if err != nil {
 		return 0, err
 	}
-	return self.ticker(client.ChainId, market)
+
+	dstChainId, err := flag.DstChainId()
+	if err != nil {
+		return 0, err
+	}
+	if dstChainId != 0 {
+		return self.crossChainTicker(client, dstChainId, market, side)
+	}
+
+	source, err := flag.GetPriceSource()
+	if err != nil {
+		return 0, err
+	}
+	if source == flag.PRICE_SOURCE_COINGECKO {
+		return self.ticker(client.ChainId, market)
+	}
+
+	asset, quote, err := self.parseMarket(client.ChainId, market)
+	if err != nil {
+		return 0, err
+	}
+	assetDec, err := asset.getDecimals(self.coingecko, client.ChainId)
+	if err != nil {
+		return 0, err
+	}
+	quoteDec, err := quote.getDecimals(self.coingecko, client.ChainId)
+	if err != nil {
+		return 0, err
+	}
+
+	// read the price from the Uniswap v3 pool with the most liquidity
+	if source == flag.PRICE_SOURCE_UNISWAP {
+		web3, err := web3.New(client.ChainId)
+		if err != nil {
+			return 0, err
+		}
+		return web3.UniswapV3Price(client.ChainId, asset.address, quote.address, assetDec, quoteDec)
+	}
+
+	// ask 1inch how much quote asset you would receive for one unit of the base asset
+	amount, _ := new(big.Float).SetFloat64(math.Pow(10, float64(assetDec))).Int(nil)
+	received, err := client.GetQuote(web3.Checksum(asset.address), web3.Checksum(quote.address), amount)
+	if err != nil {
+		return 0, err
+	}
+	out, _ := new(big.Float).Quo(new(big.Float).SetInt(received), new(big.Float).SetFloat64(math.Pow(10, float64(quoteDec)))).Float64()
+	return out, nil
+}
+
+func (self *OneInch) Validate(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
+	return self.ValidateWithPermit(market, side, size, price, days, postOnly, nil)
+}
+
+func (self *OneInch) ValidateWithPermit(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool, permit *oneinch.Permit) error {
+	client, err := oneinch.ReadWrite()
+	if err != nil {
+		return err
+	}
+	dstChainId, err := flag.DstChainId()
+	if err != nil {
+		return err
+	}
+	if dstChainId != 0 {
+		if days > 0 {
+			return ErrCrossChainDays
+		}
+		if permit != nil {
+			return ErrCrossChainPermit
+		}
+		return self.crossChainOrder(client, dstChainId, market, side, size, price, client.ValidateCrossChainOrder)
+	}
+	return self.order(client, market, side, size, price, days, permit, client.ValidateOrder)
 }
 
 func newOneInch() Exchange {
@@ -0,0 +1,131 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/svanas/ladder/api/paper"
+	consts "github.com/svanas/ladder/constants"
+)
+
+type Paper struct {
+	*info
+}
+
+func (self *Paper) Cancel(market string, side consts.OrderSide) error {
+	client, err := paper.New()
+	if err != nil {
+		return err
+	}
+
+	for _, order := range client.GetOpenOrders(market, side) {
+		if err := client.CancelOrder(order.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *Paper) FormatSymbol(asset string) (string, error) {
+	return strings.ToUpper(asset), nil
+}
+
+func (self *Paper) FormatMarket(asset, quote string) (string, error) {
+	return strings.ToUpper(fmt.Sprintf("%s-%s", asset, quote)), nil
+}
+
+func (self *Paper) Info() *info {
+	return self.info
+}
+
+func (self *Paper) Order(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
+	client, err := paper.New()
+	if err != nil {
+		return err
+	}
+	if _, err := client.CreateOrder(market, side, func() float64 {
+		out, _ := size.Float64()
+		return out
+	}(), func() float64 {
+		out, _ := price.Float64()
+		return out
+	}(), expiresAt(days), postOnly); err != nil {
+		if errors.Is(err, paper.ErrPostOnly) {
+			return fmt.Errorf("%w: %v", ErrPostOnly, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (self *Paper) Orders(market string, side consts.OrderSide) ([]Order, error) {
+	client, err := paper.New()
+	if err != nil {
+		return nil, err
+	}
+
+	var output []Order
+	for _, order := range client.GetOpenOrders(market, side) {
+		output = append(output, Order{
+			Size:  order.Size,
+			Price: order.Price,
+			Expiry: func() time.Time {
+				if order.Expiry > 0 {
+					return time.Unix(order.Expiry, 0)
+				}
+				return time.Time{}
+			}(),
+			Id: order.Id,
+		})
+	}
+
+	return output, nil
+}
+
+func (self *Paper) Precision(market string, side consts.OrderSide) (*Precision, error) {
+	client, err := paper.New()
+	if err != nil {
+		return nil, err
+	}
+	price, size := client.GetPrecision()
+	return &Precision{
+		Price: price,
+		Size:  size,
+	}, nil
+}
+
+func (self *Paper) Ticker(market string, side consts.OrderSide) (float64, error) {
+	client, err := paper.New()
+	if err != nil {
+		return 0, err
+	}
+	return client.GetTicker(market)
+}
+
+func (self *Paper) Validate(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
+	client, err := paper.New()
+	if err != nil {
+		return err
+	}
+	return client.ValidateOrder(market, side, func() float64 {
+		out, _ := size.Float64()
+		return out
+	}(), func() float64 {
+		out, _ := price.Float64()
+		return out
+	}(), expiresAt(days), postOnly)
+}
+
+func newPaper() Exchange {
+	return &Paper{
+		info: &info{
+			code: "PAPR",
+			name: "Paper",
+		},
+	}
+}
@@ -4,19 +4,28 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"net/url"
+	"os"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/hdwallet"
 )
 
-// func getBool(name string) bool {
-// 	if exists(name) {
-// 		value := get(name)
-// 		return value == "" || value[0] == 'T' || value[0] == 't' || value[0] == 'Y' || value[0] == 'y'
-// 	}
-// 	return false
-// }
+func getBool(name string) bool {
+	if exists(name) {
+		value := get(name)
+		return value == "" || value[0] == 'T' || value[0] == 't' || value[0] == 'Y' || value[0] == 'y'
+	}
+	return false
+}
 
 func getString(name string) (string, error) {
 	if exists(name) {
@@ -48,6 +57,45 @@ func Mult(cmd cobra.Command) (float64, error) {
 	return value, err
 }
 
+type DryRun int
+
+const (
+	DRY_RUN_FALSE    DryRun = iota // place the orders
+	DRY_RUN_TRUE                   // display the orders without placing them
+	DRY_RUN_VALIDATE               // have the exchange validate the orders without placing them
+)
+
+// --dry-run=[true|false|validate]
+func GetDryRun(cmd cobra.Command) (DryRun, error) {
+	value, err := cmd.Flags().GetString(consts.FLAG_DRY_RUN)
+	if err != nil {
+		return DRY_RUN_TRUE, err
+	}
+	if strings.EqualFold(value, "validate") {
+		return DRY_RUN_VALIDATE, nil
+	}
+	dry_run, err := strconv.ParseBool(value)
+	if err != nil {
+		return DRY_RUN_TRUE, fmt.Errorf("--%s is invalid. valid values are \"true\", \"false\" or \"validate\"", consts.FLAG_DRY_RUN)
+	}
+	if dry_run {
+		return DRY_RUN_TRUE, nil
+	}
+	return DRY_RUN_FALSE, nil
+}
+
+// --dry-run=[true|false], for the commands that have nothing to validate
+func IsDryRun(cmd cobra.Command) (bool, error) {
+	dry_run, err := GetDryRun(cmd)
+	if err != nil {
+		return true, err
+	}
+	if dry_run == DRY_RUN_VALIDATE {
+		return true, fmt.Errorf("--%s=validate is only supported by sell and buy", consts.FLAG_DRY_RUN)
+	}
+	return dry_run == DRY_RUN_TRUE, nil
+}
+
 // --api-key=XXX
 func ApiKey() (string, error) {
 	return getString(consts.FLAG_API_KEY)
@@ -68,6 +116,23 @@ func ApiSecret() (string, error) {
 	return string(buf), nil
 }
 
+// decrypts a V3 keystore file with a passphrase that we prompt for
+func decrypt(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := Passphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt %s: %v", path, err)
+	}
+	return crypto.FromECDSA(key.PrivateKey), nil
+}
+
 // --chain-id=[1..2147483647]
 func ChainId() (int64, error) {
 	value, err := getInt(consts.FLAG_CHAIN_ID)
@@ -79,11 +144,109 @@ func ChainId() (int64, error) {
 	return value, err
 }
 
-// --private-key=['0'..'9', 'A'..'F']
+// --dst-chain-id=[1..2147483647]
+// returns the chain your orders settle on, or zero if your orders settle on --chain-id
+func DstChainId() (int64, error) {
+	if get(consts.FLAG_DST_CHAIN_ID) == "" {
+		return 0, nil
+	}
+	value, err := getInt(consts.FLAG_DST_CHAIN_ID)
+	if err != nil || value < 1 || value > math.MaxInt32 {
+		return 0, fmt.Errorf("--%s is invalid. valid values are between 1 and %d", consts.FLAG_DST_CHAIN_ID, math.MaxInt32)
+	}
+	src, err := ChainId()
+	if err != nil {
+		return 0, err
+	}
+	if value == src {
+		return 0, nil
+	}
+	return value, nil
+}
+
+// --mnemonic="word1 word2 ..." or --mnemonic=-
+// returns your BIP-39 mnemonic. if you didn't include it (or you included --mnemonic=-), we prompt for it.
+func Mnemonic() (string, error) {
+	if str := strings.TrimSpace(get(consts.FLAG_MNEMONIC)); str != "" && str != "-" {
+		// --mnemonic word1 word2 ... binds word1 only
+		if len(strings.Fields(str)) == 1 {
+			return "", fmt.Errorf("--%s is invalid, please quote your mnemonic (for example --%s=\"word1 word2 ...\") or include --%s=- to be prompted for it", consts.FLAG_MNEMONIC, consts.FLAG_MNEMONIC, consts.FLAG_MNEMONIC)
+		}
+		return str, nil
+	}
+	buf, err := prompt("mnemonic")
+	if err != nil {
+		return "", err
+	}
+	if len(buf) == 0 {
+		return "", fmt.Errorf("--%s is empty", consts.FLAG_MNEMONIC)
+	}
+	return string(buf), nil
+}
+
+// --account-index=[0..] or --derivation-path=m/44'/60'/0'/0/0
+// returns the derivation path of your account. the default is m/44'/60'/0'/0/0, the first account of your mnemonic.
+func DerivationPath() (accounts.DerivationPath, error) {
+	index, path := get(consts.FLAG_ACCOUNT_INDEX), get(consts.FLAG_DERIVATION)
+	if index != "" && path != "" {
+		return nil, fmt.Errorf("please include --%s or --%s with your command line, not both", consts.FLAG_ACCOUNT_INDEX, consts.FLAG_DERIVATION)
+	}
+	if path != "" {
+		out, err := accounts.ParseDerivationPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("--%s is invalid: %v", consts.FLAG_DERIVATION, err)
+		}
+		return out, nil
+	}
+	out := make(accounts.DerivationPath, len(accounts.DefaultBaseDerivationPath))
+	copy(out, accounts.DefaultBaseDerivationPath)
+	if index != "" {
+		i, err := strconv.ParseUint(index, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("--%s is invalid. %s is not an account index", consts.FLAG_ACCOUNT_INDEX, index)
+		}
+		out[len(out)-1] = uint32(i)
+	}
+	return out, nil
+}
+
+// returns true if you want to derive your private key from a mnemonic
+func hd() bool {
+	return exists(consts.FLAG_MNEMONIC) || exists(consts.FLAG_ACCOUNT_INDEX) || exists(consts.FLAG_DERIVATION)
+}
+
+// derives your private key from your mnemonic (that we prompt for, unless you included it)
+func derive() ([]byte, error) {
+	path, err := DerivationPath()
+	if err != nil {
+		return nil, err
+	}
+	mnemonic, err := Mnemonic()
+	if err != nil {
+		return nil, err
+	}
+	seed, err := hdwallet.NewSeed(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hdwallet.Derive(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.FromECDSA(key), nil
+}
+
+// --private-key=['0'..'9', 'A'..'F'], --keystore=path/to/keystore.json, or --mnemonic with --account-index (or --derivation-path)
 func PrivateKey() ([]byte, error) {
 	if str := get(consts.FLAG_PRIVATE_KEY); str != "" {
 		return hex.DecodeString(str)
 	}
+	if path := get(consts.FLAG_KEYSTORE); path != "" {
+		return decrypt(path)
+	}
+	if hd() {
+		return derive()
+	}
 	buf, err := prompt("private key")
 	if err != nil {
 		return nil, err
@@ -93,3 +256,263 @@ func PrivateKey() ([]byte, error) {
 	}
 	return hex.DecodeString(string(buf))
 }
+
+// --sandbox, --base-url=https://...
+// returns the base URL of the exchange's API; the production URL unless the user overrides it.
+// an empty sandbox means the exchange does not offer a sandbox.
+func BaseURL(production, sandbox string) (string, error) {
+	if str := get(consts.FLAG_BASE_URL); str != "" {
+		if _, err := url.ParseRequestURI(str); err != nil {
+			return "", fmt.Errorf("--%s is invalid: %v", consts.FLAG_BASE_URL, err)
+		}
+		return strings.TrimSuffix(str, "/"), nil
+	}
+	if getBool(consts.FLAG_SANDBOX) {
+		if sandbox == "" {
+			return "", fmt.Errorf("--%s is not supported by this exchange, please use --%s instead", consts.FLAG_SANDBOX, consts.FLAG_BASE_URL)
+		}
+		return sandbox, nil
+	}
+	return production, nil
+}
+
+type Permit int
+
+const (
+	PERMIT_NONE    Permit = iota // approve the exchange with an on-chain transaction
+	PERMIT_EIP2612               // sign an EIP-2612 permit
+	PERMIT_PERMIT2               // sign a Uniswap Permit2 permit
+)
+
+// --permit=[eip2612|permit2]
+func GetPermit() (Permit, error) {
+	switch value := strings.ToLower(get(consts.FLAG_PERMIT)); value {
+	case "", "none":
+		return PERMIT_NONE, nil
+	case "eip2612":
+		return PERMIT_EIP2612, nil
+	case "permit2":
+		return PERMIT_PERMIT2, nil
+	}
+	return PERMIT_NONE, fmt.Errorf("--%s is invalid. valid values are \"eip2612\" or \"permit2\"", consts.FLAG_PERMIT)
+}
+
+type PriceSource int
+
+const (
+	PRICE_SOURCE_1INCH     PriceSource = iota // the 1inch quote for one unit of the base asset
+	PRICE_SOURCE_UNISWAP                      // the Uniswap v3 pool with the most liquidity
+	PRICE_SOURCE_COINGECKO                    // the ratio between two CoinGecko USD prices
+)
+
+// --price-source=[1inch|uniswap|coingecko]
+func GetPriceSource() (PriceSource, error) {
+	switch value := strings.ToLower(get(consts.FLAG_PRICE_SOURCE)); value {
+	case "", "1inch":
+		return PRICE_SOURCE_1INCH, nil
+	case "uniswap":
+		return PRICE_SOURCE_UNISWAP, nil
+	case "coingecko":
+		return PRICE_SOURCE_COINGECKO, nil
+	}
+	return PRICE_SOURCE_1INCH, fmt.Errorf("--%s is invalid. valid values are \"1inch\", \"uniswap\" or \"coingecko\"", consts.FLAG_PRICE_SOURCE)
+}
+
+// returns the (checksummed) address, or an empty string if the flag is absent
+func getAddress(name string) (string, error) {
+	str, err := lookup(name)
+	if err != nil || str == "" {
+		return "", err
+	}
+	if !strings.HasPrefix(str, "0x") || !common.IsHexAddress(str) {
+		return "", fmt.Errorf("--%s is invalid. %s is not an address", name, str)
+	}
+	address := common.HexToAddress(str).Hex()
+	// a mixed-case address needs to have a valid EIP-55 checksum
+	if str != strings.ToLower(str) && str[2:] != strings.ToUpper(str[2:]) && str != address {
+		return "", fmt.Errorf("--%s is invalid. %s has an invalid checksum, did you mean %s?", name, str, address)
+	}
+	return address, nil
+}
+
+// --rpc=https://...,https://...
+// returns the RPC endpoints you want to use, or nil if you didn't include any
+func RPC() ([]string, error) {
+	str := get(consts.FLAG_RPC)
+	if str == "" {
+		return nil, nil
+	}
+	var out []string
+	for _, endpoint := range strings.Split(str, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if _, err := url.ParseRequestURI(endpoint); err != nil {
+			return nil, fmt.Errorf("--%s is invalid: %v", consts.FLAG_RPC, err)
+		}
+		out = append(out, endpoint)
+	}
+	return out, nil
+}
+
+// --asset-address=0x... or --quote-address=0x...
+// returns the address you pinned for this symbol (the symbol being your --asset or your --quote), or an empty string if you didn't
+func PinnedAddress(symbol string) (string, error) {
+	for _, pin := range []struct{ symbol, address string }{
+		{consts.FLAG_ASSET, consts.FLAG_ASSET_ADDRESS},
+		{consts.FLAG_QUOTE, consts.FLAG_QUOTE_ADDRESS},
+	} {
+		if strings.EqualFold(get(pin.symbol), symbol) {
+			address, err := getAddress(pin.address)
+			if err != nil || address != "" {
+				return address, err
+			}
+		}
+	}
+	return "", nil
+}
+
+// --receiver=0x...
+// returns the address that will receive the proceeds of your orders, or an empty string if that's you
+func Receiver() (string, error) {
+	return getAddress(consts.FLAG_RECEIVER)
+}
+
+// --allowed-sender=0x...
+// returns the only address that is allowed to fill your orders, or an empty string if anyone can
+func AllowedSender() (string, error) {
+	return getAddress(consts.FLAG_ALLOWED_SENDER)
+}
+
+// --maker=0x...
+// returns the address of the maker, or an empty string if the maker is the owner of your private key
+func Maker() (string, error) {
+	return getAddress(consts.FLAG_MAKER)
+}
+
+// Activation is the condition your (DEX) orders need to meet before they can be filled. if Above is lower than Below,
+// your orders activate between these prices. otherwise, your orders activate above Above or below Below (whichever
+// comes first).
+type Activation struct {
+	Oracle string    // the Chainlink price feed
+	Above  float64   // the oracle price your orders activate above, or zero
+	Below  float64   // the oracle price your orders activate below, or zero
+	At     time.Time // the time your orders activate at, or zero
+}
+
+// returns the (positive) price, or zero if the flag is absent
+func getPrice(name string) (float64, error) {
+	str, err := lookup(name)
+	if err != nil || str == "" {
+		return 0, err
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("--%s is invalid. %s is not a price", name, str)
+	}
+	return value, nil
+}
+
+// returns the time, or zero if the flag is absent. the time is a unix timestamp, a RFC 3339 time or a date.
+func getTime(name string) (time.Time, error) {
+	str, err := lookup(name)
+	if err != nil || str == "" {
+		return time.Time{}, err
+	}
+	if unix, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, str, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("--%s is invalid. %s is not a unix timestamp, a RFC 3339 time or a date", name, str)
+}
+
+// --oracle=0x... --activate-above=[price] --activate-below=[price] --activate-at=[time]
+// returns the condition your orders need to meet before they can be filled, or nil if you didn't include any
+func GetActivation() (*Activation, error) {
+	var (
+		out Activation
+		err error
+	)
+	if out.Oracle, err = getAddress(consts.FLAG_ORACLE); err != nil {
+		return nil, err
+	}
+	if out.Above, err = getPrice(consts.FLAG_ACTIVATE_ABOVE); err != nil {
+		return nil, err
+	}
+	if out.Below, err = getPrice(consts.FLAG_ACTIVATE_BELOW); err != nil {
+		return nil, err
+	}
+	if out.At, err = getTime(consts.FLAG_ACTIVATE_AT); err != nil {
+		return nil, err
+	}
+	if out.Above == 0 && out.Below == 0 && out.At.IsZero() {
+		if out.Oracle != "" {
+			return nil, fmt.Errorf("--%s is useless without --%s or --%s", consts.FLAG_ORACLE, consts.FLAG_ACTIVATE_ABOVE, consts.FLAG_ACTIVATE_BELOW)
+		}
+		return nil, nil
+	}
+	if (out.Above > 0 || out.Below > 0) && out.Oracle == "" {
+		return nil, fmt.Errorf("please include --%s (the address of a Chainlink price feed) with your command line", consts.FLAG_ORACLE)
+	}
+	return &out, nil
+}
+
+// Auction is a Dutch auction: every order starts at a premium, and its price decays (linearly) to the order's price
+type Auction struct {
+	Premium float64       // the premium every order starts at, in percent
+	Window  time.Duration // the time it takes for the premium to decay to zero
+}
+
+// returns the price the order starts at
+func (auction *Auction) StartPrice(side consts.OrderSide, price float64) float64 {
+	if side == consts.BUY {
+		return price / (1 + auction.Premium/100)
+	}
+	return price * (1 + auction.Premium/100)
+}
+
+// --premium=[percent] --decay=[duration]
+// returns the Dutch auction your orders will start with, or nil if you didn't include --premium
+func GetAuction() (*Auction, error) {
+	var premium float64
+	str, err := lookup(consts.FLAG_PREMIUM)
+	if err != nil {
+		return nil, err
+	}
+	if str != "" {
+		if premium, err = strconv.ParseFloat(str, 64); err != nil || premium <= 0 || premium >= 100 {
+			return nil, fmt.Errorf("--%s is invalid. valid values are between 0 and 100 (percent)", consts.FLAG_PREMIUM)
+		}
+	}
+	if str, err = lookup(consts.FLAG_DECAY); err != nil {
+		return nil, err
+	}
+	if premium == 0 {
+		if str != "" {
+			return nil, fmt.Errorf("--%s is useless without --%s", consts.FLAG_DECAY, consts.FLAG_PREMIUM)
+		}
+		return nil, nil
+	}
+	if str == "" {
+		return nil, fmt.Errorf("please include --%s (for example: 6h) with your command line", consts.FLAG_DECAY)
+	}
+	window, err := time.ParseDuration(str)
+	if err != nil || window <= 0 {
+		return nil, fmt.Errorf("--%s is invalid. %s is not a duration", consts.FLAG_DECAY, str)
+	}
+	return &Auction{Premium: premium, Window: window}, nil
+}
+
+// --epoch
+// returns true if you want to invalidate every order you have by increasing your epoch, rather than cancelling your
+// orders one by one. this includes the orders 1inch doesn't list, in every market.
+func Epoch() bool {
+	return getBool(consts.FLAG_EPOCH)
+}
+
+// --paper-state=path/to/paper.json
+func PaperState() string {
+	return get(consts.FLAG_PAPER_STATE)
+}
@@ -8,8 +8,22 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// the command that is running, or nil if cobra hasn't parsed its flags (yet)
+var running *cobra.Command
+
+// Parse() remembers the command that is running, so that we read its flags with or without the equal sign
+func Parse(cmd *cobra.Command) {
+	running = cmd
+}
+
 // Get() finds a named flag in the args list and returns its value
 func get(name string) string {
+	if running != nil {
+		if f := running.Flags().Lookup(name); f != nil && f.Changed {
+			return f.Value.String()
+		}
+		return ""
+	}
 	for _, arg := range os.Args {
 		if strings.HasPrefix(arg, "-"+name) || strings.HasPrefix(arg, "--"+name) {
 			i := strings.Index(arg, "=")
@@ -25,6 +39,10 @@ func get(name string) string {
 
 // Exists() determines if a flag exists, even if it doesn't have a value
 func exists(name string) bool {
+	if running != nil {
+		f := running.Flags().Lookup(name)
+		return f != nil && f.Changed
+	}
 	for _, arg := range os.Args {
 		if strings.HasPrefix(arg, "-"+name) || strings.HasPrefix(arg, "--"+name) {
 			return true
@@ -33,6 +51,15 @@ func exists(name string) bool {
 	return false
 }
 
+// lookup() returns the value of a named flag, an empty string if the flag doesn't exist, or an error if the flag exists without a value
+func lookup(name string) (string, error) {
+	value := strings.TrimSpace(get(name))
+	if value == "" && exists(name) {
+		return "", fmt.Errorf("--%s is empty", name)
+	}
+	return value, nil
+}
+
 func GetFloat64(cmd cobra.Command, name string) (float64, error) {
 	out, err := cmd.Flags().GetFloat64(name)
 	if out == 0 && err == nil {
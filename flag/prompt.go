@@ -1,6 +1,7 @@
 package flag
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"syscall"
@@ -8,8 +9,13 @@ import (
 	"golang.org/x/term"
 )
 
-// reads a line of input from a terminal without local echo. this is commonly used for inputting passwords and other sensitive data.
+// prompts for a line of input from a terminal without local echo. this is commonly used for inputting passwords and other sensitive data.
 func prompt(name string) ([]byte, error) {
+	return read(fmt.Sprintf("Please paste your %s: ", name))
+}
+
+// reads a line of input from a terminal without local echo
+func read(message string) ([]byte, error) {
 	var (
 		err error
 		tty *os.File
@@ -20,7 +26,7 @@ func prompt(name string) ([]byte, error) {
 	} else {
 		tty = os.Stderr
 	}
-	fmt.Fprintf(tty, "Please paste your %s: ", name)
+	fmt.Fprint(tty, message)
 	var buf []byte
 	if buf, err = term.ReadPassword(int(fd)); err != nil {
 		return nil, err
@@ -28,3 +34,21 @@ func prompt(name string) ([]byte, error) {
 	fmt.Fprintln(tty)
 	return buf, nil
 }
+
+// Passphrase prompts for the passphrase of your keystore. if confirm is true, the passphrase needs to be entered twice.
+func Passphrase(confirm bool) (string, error) {
+	buf, err := read("Please enter your passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		again, err := read("Please repeat your passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if string(buf) != string(again) {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,121 @@
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/text/unicode/norm"
+)
+
+// the BIP-39 English wordlist, please see https://github.com/bitcoin/bips/blob/master/bip-0039/english.txt
+//
+//go:embed english.txt
+var english string
+
+// returns the index of every word in the wordlist
+var wordlist = func() map[string]int {
+	out := make(map[string]int)
+	for index, word := range strings.Fields(english) {
+		out[word] = index
+	}
+	return out
+}()
+
+// verifies that every word is in the wordlist, and that the last word has the checksum of the mnemonic
+func verify(words []string) error {
+	// every word has 11 bits, the last (1/33rd) of these bits being the checksum of the bits that precede them
+	bits := new(big.Int)
+	for _, word := range words {
+		index, ok := wordlist[word]
+		if !ok {
+			return fmt.Errorf("%s is not a BIP-39 word", word)
+		}
+		bits.Lsh(bits, 11).Or(bits, big.NewInt(int64(index)))
+	}
+	size := len(words) * 11 / 33 // the size of the checksum, in bits
+	checksum := new(big.Int).And(bits, big.NewInt(int64(1<<size-1)))
+	entropy := math.PaddedBigBytes(new(big.Int).Rsh(bits, uint(size)), size*4)
+	hash := sha256.Sum256(entropy)
+	if uint64(hash[0]>>(8-size)) != checksum.Uint64() {
+		return errors.New("invalid mnemonic checksum, please make sure you didn't make a typo")
+	}
+	return nil
+}
+
+// NewSeed returns the BIP-39 seed of a mnemonic, please see https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
+func NewSeed(mnemonic string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, fmt.Errorf("a mnemonic has 12, 15, 18, 21 or 24 words, not %d", len(words))
+	}
+	if err := verify(words); err != nil {
+		return nil, err
+	}
+	return pbkdf2.Key(sha512.New, norm.NFKD.String(strings.Join(words, " ")), []byte("mnemonic"), 2048, 64)
+}
+
+// returns the key and the chain code of the child at index i, please see
+// https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki#private-parent-key--private-child-key
+func child(key *ecdsa.PrivateKey, chainCode []byte, i uint32) (*ecdsa.PrivateKey, []byte, error) {
+	var data []byte
+	if i >= 0x80000000 { // hardened
+		data = append([]byte{0}, math.PaddedBigBytes(key.D, 32)...)
+	} else {
+		data = crypto.CompressPubkey(&key.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, nil, errors.New("invalid child key, please use another account")
+	}
+	d := new(big.Int).Mod(new(big.Int).Add(il, key.D), crypto.S256().Params().N)
+	if d.Sign() == 0 {
+		return nil, nil, errors.New("invalid child key, please use another account")
+	}
+
+	out, err := crypto.ToECDSA(math.PaddedBigBytes(d, 32))
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, sum[32:], nil
+}
+
+// Derive returns the private key at this (BIP-32) derivation path, for example m/44'/60'/0'/0/0
+func Derive(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key, err := crypto.ToECDSA(sum[:32])
+	if err != nil {
+		return nil, err
+	}
+	chainCode := sum[32:]
+
+	for _, i := range path {
+		if key, chainCode, err = child(key, chainCode, i); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
@@ -0,0 +1,102 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestNewSeed(t *testing.T) {
+	for _, test := range []struct {
+		mnemonic string
+		seed     string
+		err      string
+	}{
+		{
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seed:     "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4",
+		},
+		{
+			mnemonic: "  Abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon ABOUT ",
+			seed:     "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4",
+		},
+		{
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			err:      "checksum",
+		},
+		{
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abuot",
+			err:      "not a BIP-39 word",
+		},
+		{
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			err:      "12, 15, 18, 21 or 24 words",
+		},
+		{
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+		},
+	} {
+		seed, err := NewSeed(test.mnemonic)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%q: expected an error that contains %q, got %v", test.mnemonic, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.mnemonic, err)
+			continue
+		}
+		if test.seed != "" && hex.EncodeToString(seed) != test.seed {
+			t.Errorf("%q: expected seed %s, got %x", test.mnemonic, test.seed, seed)
+		}
+	}
+}
+
+func TestDeriveMnemonic(t *testing.T) {
+	seed, err := NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := Derive(seed, accounts.DefaultBaseDerivationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address := crypto.PubkeyToAddress(key.PublicKey).Hex(); address != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Errorf("expected 0x9858EfFD232B4033E47d90003D41EC34EcaEda94, got %s", address)
+	}
+}
+
+// BIP-32 test vector 1, please see https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki#test-vector-1
+func TestDeriveVector1(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	for _, test := range []struct {
+		path string
+		key  string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0'/1/2'/2", "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	} {
+		path := accounts.DerivationPath{}
+		if test.path != "m" {
+			var err error
+			if path, err = accounts.ParseDerivationPath(test.path); err != nil {
+				t.Fatal(err)
+			}
+		}
+		key, err := Derive(seed, path)
+		if err != nil {
+			t.Fatalf("%s: %v", test.path, err)
+		}
+		if got := hex.EncodeToString(crypto.FromECDSA(key)); got != test.key {
+			t.Errorf("%s: expected %s, got %s", test.path, test.key, got)
+		}
+	}
+}
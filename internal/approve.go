@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/svanas/ladder/answer"
+	"github.com/svanas/ladder/exchange"
+)
+
+// print an (estimated or broadcasted) approval to standard output
+func PrintApproval(title string, approval *exchange.Approval) {
+	tbl := table.NewWriter()
+	tbl.AppendHeader(table.Row{title, title}, table.RowConfig{AutoMerge: true})
+	tbl.AppendRows([]table.Row{
+		{"Asset", approval.Asset},
+		{"Allowance", strconv.FormatFloat(approval.Amount, 'f', -1, 64)},
+	})
+	if approval.Reset {
+		tbl.AppendRow(table.Row{"Reset to zero first", "Yes"})
+	}
+	tbl.AppendRow(table.Row{"Gas (at most)", fmt.Sprintf("%s %s", strconv.FormatFloat(approval.Cost, 'f', -1, 64), approval.Coin)})
+	if approval.TxHash != "" {
+		tbl.AppendRow(table.Row{"Transaction", approval.TxHash})
+	}
+	fmt.Println(tbl.Render())
+	if approval.Batch != nil {
+		if err := exchange.PrintSafeBatch(approval.Batch); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// Approve raises the allowance of asset to what your open orders still need plus amount, unless the current allowance
+// suffices. prompts before broadcasting.
+func Approve(approver exchange.Approver, asset string, total float64) error {
+	amount, err := approver.Scale(asset, total)
+	if err != nil {
+		return err
+	}
+	committed, err := approver.Committed(asset)
+	if err != nil {
+		return err
+	}
+	amount.Add(amount, committed)
+
+	allowance, err := approver.Allowance(asset)
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+
+	approval, err := approver.Approve(asset, amount, true)
+	if err != nil {
+		return err
+	}
+	PrintApproval("Approve this allowance?", approval)
+	if answer.Ask() == answer.NO {
+		return nil
+	}
+
+	approval, err = approver.Approve(asset, amount, false)
+	if err != nil {
+		return err
+	}
+	if approval.Batch != nil {
+		PrintApproval("Safe transaction", approval)
+		return exchange.ErrSafeTransaction
+	}
+	PrintApproval("Approved", approval)
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/exchange"
+)
+
+// Ladder is a series of limit orders between two prices, on one side of the market
+type Ladder struct {
+	Side          consts.OrderSide
+	StartAtPrice  float64 // you sell from low to high, and you buy from high to low
+	StopAtPrice   float64
+	StartWithSize float64 // the size of the first order, in base asset
+	Mult          float64
+	Target        *Target // the notional the orders add up to (exactly), if you want to sweep the dust from your wallet
+	Steps         int
+}
+
+// NewLadder returns the ladder that adds up to size. when you sell, start_with_size and size are in base asset. when you
+// buy, they are in quote asset.
+func NewLadder(side consts.OrderSide, start_at_price, stop_at_price, start_with_size, mult, size float64, sweep_dust bool) *Ladder {
+	// sell from low to high, buy from high to low
+	if (side == consts.SELL && start_at_price > stop_at_price) || (side == consts.BUY && start_at_price < stop_at_price) {
+		stop_at_price, start_at_price = start_at_price, stop_at_price
+	}
+
+	steps := 2
+	for (side == consts.SELL && SimulateSell(start_with_size, mult, steps) < size) ||
+		(side == consts.BUY && SimulateBuy(start_at_price, stop_at_price, start_with_size, mult, steps) < size) {
+		steps++
+	}
+	steps--
+
+	out := &Ladder{
+		Side:          side,
+		StartAtPrice:  start_at_price,
+		StopAtPrice:   stop_at_price,
+		StartWithSize: start_with_size,
+		Mult:          mult,
+		Steps:         steps,
+	}
+	if side == consts.BUY {
+		out.StartWithSize = start_with_size / start_at_price
+	}
+	if sweep_dust {
+		out.Target = &Target{Side: side, Notional: size}
+	}
+	return out
+}
+
+// Orders returns every order, rounded to the precision of the market
+func (ladder *Ladder) Orders(prec exchange.Precision) []exchange.Order {
+	return Orders(ladder.StartAtPrice, ladder.StopAtPrice, ladder.StartWithSize, ladder.Mult, ladder.Target, ladder.Steps, prec)
+}
+
+// Open returns the orders that wouldn't be filled immediately: above the ticker when you sell, below the ticker when you
+// buy. a conditional order cannot be filled before it activates, so if your orders have an activation, every order is open.
+func (ladder *Ladder) Open(exc exchange.Exchange, market string, prec exchange.Precision, activation bool) ([]exchange.Order, error) {
+	orders := ladder.Orders(prec)
+	if activation {
+		return orders, nil
+	}
+	ticker, err := exc.Ticker(market, ladder.Side)
+	if err != nil {
+		return nil, err
+	}
+	return ladder.open(orders, ticker), nil
+}
+
+// returns the orders that wouldn't be filled immediately. a ticker of -1 means the price is unknown (for example: the
+// price source doesn't know about the token), in which case every order is open.
+func (ladder *Ladder) open(orders []exchange.Order, ticker float64) []exchange.Order {
+	if ticker == -1 {
+		return orders
+	}
+	var out []exchange.Order
+	for _, order := range orders {
+		if (ladder.Side == consts.SELL && order.Price > ticker) || (ladder.Side == consts.BUY && order.Price < ticker) {
+			out = append(out, order)
+		}
+	}
+	return out
+}
+
+// Print prints every order to standard output, see Print
+func (ladder *Ladder) Print(exc exchange.Exchange, asset, quote string, prec exchange.Precision, validation []error) {
+	Print(exc, ladder.Side, asset, quote, ladder.StartAtPrice, ladder.StopAtPrice, ladder.StartWithSize, ladder.Mult, ladder.Target, ladder.Steps, prec, validation)
+}
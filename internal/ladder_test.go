@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"testing"
+
+	consts "github.com/svanas/ladder/constants"
+	"github.com/svanas/ladder/exchange"
+)
+
+func TestOpen(t *testing.T) {
+	prec := exchange.Precision{Price: 2, Size: 4}
+	for _, test := range []struct {
+		name   string
+		ladder *Ladder
+		ticker float64
+		want   func(orders []exchange.Order) int
+	}{
+		{"sell above the ticker", NewLadder(consts.SELL, 100, 200, 1, 1, 5, false), 50, every},
+		{"sell below the ticker", NewLadder(consts.SELL, 100, 200, 1, 1, 5, false), 250, none},
+		{"sell across the ticker", NewLadder(consts.SELL, 100, 200, 1, 1, 5, false), 150, above(150)},
+		{"sell with an unknown ticker", NewLadder(consts.SELL, 100, 200, 1, 1, 5, false), -1, every},
+		{"buy below the ticker", NewLadder(consts.BUY, 200, 100, 200, 1, 1000, false), 250, every},
+		{"buy above the ticker", NewLadder(consts.BUY, 200, 100, 200, 1, 1000, false), 50, none},
+		{"buy across the ticker", NewLadder(consts.BUY, 200, 100, 200, 1, 1000, false), 150, below(150)},
+		{"buy with an unknown ticker", NewLadder(consts.BUY, 200, 100, 200, 1, 1000, false), -1, every},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			orders := test.ladder.Orders(prec)
+			if len(orders) < 2 {
+				t.Fatalf("got %d orders, want at least 2", len(orders))
+			}
+			want := test.want(orders)
+			if got := test.ladder.open(orders, test.ticker); len(got) != want {
+				t.Errorf("got %d open orders, want %d", len(got), want)
+			}
+		})
+	}
+}
+
+func every(orders []exchange.Order) int {
+	return len(orders)
+}
+
+func none(orders []exchange.Order) int {
+	return 0
+}
+
+func above(ticker float64) func(orders []exchange.Order) int {
+	return func(orders []exchange.Order) int {
+		out := 0
+		for _, order := range orders {
+			if order.Price > ticker {
+				out++
+			}
+		}
+		return out
+	}
+}
+
+func below(ticker float64) func(orders []exchange.Order) int {
+	return func(orders []exchange.Order) int {
+		out := 0
+		for _, order := range orders {
+			if order.Price < ticker {
+				out++
+			}
+		}
+		return out
+	}
+}
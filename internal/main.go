@@ -6,6 +6,7 @@ import (
 	"github.com/jedib0t/go-pretty/v6/table"
 	consts "github.com/svanas/ladder/constants"
 	"github.com/svanas/ladder/exchange"
+	"github.com/svanas/ladder/flag"
 	"github.com/svanas/ladder/precision"
 )
 
@@ -81,10 +82,24 @@ func Orders(start_at_price, stop_at_price, start_with_size, mult float64, target
 	return result
 }
 
-// print every order to standard output
-func Print(asset, quote string, start_at_price, stop_at_price, start_with_size, mult float64, target *Target, steps int, prec exchange.Precision) {
+// print every order to standard output. if validation isn't empty, the exchange's verdict on every order is printed too.
+// if the orders are a Dutch auction, the price every order starts at is printed too.
+func Print(exc exchange.Exchange, side consts.OrderSide, asset, quote string, start_at_price, stop_at_price, start_with_size, mult float64, target *Target, steps int, prec exchange.Precision, validation []error) {
+	// only 1inch supports Dutch auctions
+	auction, _ := flag.GetAuction()
+	if !exchange.IsOneInch(exc) {
+		auction = nil
+	}
+
 	tbl := table.NewWriter()
-	tbl.AppendHeader(table.Row{"", "Price", "Size", "Value"})
+	header := table.Row{"", "Price", "Size", "Value"}
+	if auction != nil {
+		header = table.Row{"", "Start Price", "End Price", "Size", "Value"}
+	}
+	if len(validation) > 0 {
+		header = append(header, "Validation")
+	}
+	tbl.AppendHeader(header)
 
 	var (
 		cumulative_size  float64 = 0
@@ -111,21 +126,44 @@ func Print(asset, quote string, start_at_price, stop_at_price, start_with_size,
 		cumulative_size += current_size
 		cumulative_value += current_price * current_size
 
-		tbl.AppendRow(table.Row{step + 1,
+		row := table.Row{step + 1}
+		if auction != nil {
+			row = append(row, fmt.Sprintf("%[3]v %.[2]*[1]f", auction.StartPrice(side, current_price), prec.Price, quote))
+		}
+		row = append(row,
 			fmt.Sprintf("%[3]v %.[2]*[1]f", current_price, prec.Price, quote),
 			fmt.Sprintf("%.[2]*[1]f %[3]v", current_size, prec.Size, asset),
-			fmt.Sprintf("%[3]v %.[2]*[1]f", (current_price * current_size), prec.Price, quote),
-		})
+			fmt.Sprintf("%[3]v %.[2]*[1]f", (current_price*current_size), prec.Price, quote),
+		)
+		if step < len(validation) {
+			if validation[step] == nil {
+				row = append(row, "OK")
+			} else {
+				row = append(row, validation[step].Error())
+			}
+		}
+		tbl.AppendRow(row)
 
 		current_size = start_with_size * (1 + (float64(step+1) * (mult - 1)))
 		current_price += delta
 	}
 
 	tbl.AppendSeparator()
-	tbl.AppendRow(table.Row{"TOTAL", "",
+	total := table.Row{"TOTAL", ""}
+	if auction != nil {
+		total = append(total, "")
+	}
+	tbl.AppendRow(append(total,
 		fmt.Sprintf("%.[2]*[1]f %[3]v", cumulative_size, prec.Size, asset),
 		fmt.Sprintf("%[3]v %.[2]*[1]f", cumulative_value, prec.Price, quote),
-	})
+	))
 
 	fmt.Println(tbl.Render())
+
+	if sender, err := flag.AllowedSender(); err == nil && sender != "" && exchange.IsOneInch(exc) {
+		fmt.Printf("These orders are private. Only %s will be allowed to fill them.\n", sender)
+	}
+	if auction != nil {
+		fmt.Printf("Every order starts at a %v%% premium that decays to its end price in %v.\n", auction.Premium, auction.Window)
+	}
 }
@@ -2,15 +2,22 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/svanas/ladder/answer"
+	"github.com/svanas/ladder/api/oneinch"
+	consts "github.com/svanas/ladder/constants"
 	"github.com/svanas/ladder/exchange"
+	"github.com/svanas/ladder/flag"
+	"github.com/svanas/ladder/precision"
 )
 
-func Prompt(order exchange.Order, market string) answer.Answer {
+func Prompt(exc exchange.Exchange, order exchange.Order, market string) answer.Answer {
 	const TITLE = "Open this order?"
 
 	tbl := table.NewWriter()
@@ -20,7 +27,110 @@ func Prompt(order exchange.Order, market string) answer.Answer {
 		{"Price", strconv.FormatFloat(order.Price, 'f', -1, 64)},
 		{"Size", strconv.FormatFloat(order.Size, 'f', -1, 64)},
 	})
+	// only 1inch supports a receiver and private orders
+	if receiver, err := flag.Receiver(); err == nil && receiver != "" && exchange.IsOneInch(exc) {
+		tbl.AppendRow(table.Row{"Receiver", receiver})
+	}
+	if sender, err := flag.AllowedSender(); err == nil && sender != "" && exchange.IsOneInch(exc) {
+		tbl.AppendRow(table.Row{"Allowed sender", sender})
+	}
+	if auction, err := flag.GetAuction(); err == nil && auction != nil && exchange.IsOneInch(exc) {
+		tbl.AppendRow(table.Row{"Premium", fmt.Sprintf("%v%% (decays in %v)", auction.Premium, auction.Window)})
+	}
 	fmt.Println(tbl.Render())
 
 	return answer.Ask()
 }
+
+// Total returns the total of these orders, in the asset the exchange will spend
+func Total(side consts.OrderSide, orders []exchange.Order) float64 {
+	var out float64
+	for _, order := range orders {
+		if side == consts.SELL {
+			out += order.Size
+		} else {
+			out += order.Size * order.Price
+		}
+	}
+	return out
+}
+
+// Permit signs a permit for (exactly) amount of asset, if the exchange accepts one and you asked for one. otherwise, returns nil.
+func Permit(exc exchange.Exchange, asset string, amount float64, days int) (*oneinch.Permit, error) {
+	if permitter, ok := exc.(exchange.Permitter); ok {
+		return permitter.Permit(asset, amount, days)
+	}
+	return nil, nil
+}
+
+// CrossChain returns an error if your cross-chain ladder includes a flag that cross-chain orders do not support
+func CrossChain(exc exchange.Exchange, days int) error {
+	if !exchange.IsOneInch(exc) {
+		return nil
+	}
+	if dstChainId, err := flag.DstChainId(); err != nil || dstChainId == 0 {
+		return err
+	}
+	if days > 0 {
+		return exchange.ErrCrossChainDays
+	}
+	if permit, err := flag.GetPermit(); err != nil {
+		return err
+	} else if permit != flag.PERMIT_NONE {
+		return exchange.ErrCrossChainPermit
+	}
+	return nil
+}
+
+// Validate has the exchange validate an order without placing it. the permit (if any) gets embedded in the order.
+func Validate(exc exchange.Exchange, market string, side consts.OrderSide, order exchange.Order, days int, postOnly bool, permit *oneinch.Permit) error {
+	if permitter, ok := exc.(exchange.Permitter); ok && permit != nil {
+		return permitter.ValidateWithPermit(market, side, order.BigSize(), order.BigPrice(), days, postOnly, permit)
+	}
+	return exc.Validate(market, side, order.BigSize(), order.BigPrice(), days, postOnly)
+}
+
+// Place opens a limit order. a rejected post-only order is nudged one tick away from the spread and retried, up to `nudge`
+// times, but never beyond limit (a ceiling when you sell, a floor when you buy).
+func Place(exc exchange.Exchange, market string, side consts.OrderSide, order exchange.Order, days int, postOnly bool, nudge int, limit float64, prec exchange.Precision, permit *oneinch.Permit) error {
+	place := exc.Order
+	if permitter, ok := exc.(exchange.Permitter); ok && permit != nil {
+		place = func(market string, side consts.OrderSide, size, price big.Float, days int, postOnly bool) error {
+			return permitter.OrderWithPermit(market, side, size, price, days, postOnly, permit)
+		}
+	}
+	tick := math.Pow(10, -float64(prec.Price))
+	for {
+		err := place(market, side, order.BigSize(), order.BigPrice(), days, postOnly)
+		if err == nil || !errors.Is(err, exchange.ErrPostOnly) || nudge <= 0 {
+			return err
+		}
+		nudge--
+		price := func() float64 {
+			if side == consts.SELL {
+				return precision.Round(order.Price+tick, prec.Price)
+			}
+			return precision.Round(order.Price-tick, prec.Price)
+		}()
+		if (side == consts.SELL && price > limit) || (side == consts.BUY && price < limit) {
+			return err
+		}
+		order.Price = price
+	}
+}
+
+// print the rejected post-only orders to standard output
+func Rejected(orders []exchange.Order, asset, quote string, prec exchange.Precision) {
+	const TITLE = "Rejected post-only orders"
+
+	tbl := table.NewWriter()
+	tbl.AppendHeader(table.Row{TITLE, TITLE, TITLE}, table.RowConfig{AutoMerge: true})
+	tbl.AppendHeader(table.Row{"", "Price", "Size"})
+	for index, order := range orders {
+		tbl.AppendRow(table.Row{index + 1,
+			fmt.Sprintf("%[3]v %.[2]*[1]f", order.Price, prec.Price, quote),
+			fmt.Sprintf("%.[2]*[1]f %[3]v", order.Size, prec.Size, asset),
+		})
+	}
+	fmt.Println(tbl.Render())
+}
@@ -0,0 +1,120 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is an order with an expiry that the exchange doesn't know about
+type Entry struct {
+	Exchange string `json:"exchange"`
+	Market   string `json:"market"`
+	OrderId  string `json:"orderId"`
+	Expiry   int64  `json:"expiry"` // unix timestamp
+}
+
+func (entry *Entry) Expired() bool {
+	return time.Now().Unix() >= entry.Expiry
+}
+
+// returns the location of the journal, for example ~/.config/ladder/journal.json
+func path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ladder", "journal.json"), nil
+}
+
+func load() ([]Entry, error) {
+	path, err := path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func save(entries []Entry) error {
+	path, err := path()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Get returns every journal entry for the given exchange
+func Get(exchange string) ([]Entry, error) {
+	entries, err := load()
+	if err != nil {
+		return nil, err
+	}
+	var out []Entry
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Exchange, exchange) {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+func Add(entry Entry) error {
+	entries, err := load()
+	if err != nil {
+		return err
+	}
+	return save(append(entries, entry))
+}
+
+func Remove(exchange, orderId string) error {
+	entries, err := load()
+	if err != nil {
+		return err
+	}
+	var out []Entry
+	for _, entry := range entries {
+		if !strings.EqualFold(entry.Exchange, exchange) || entry.OrderId != orderId {
+			out = append(out, entry)
+		}
+	}
+	return save(out)
+}
+
+// Prune removes every journal entry for the given exchange that keep returns false for
+func Prune(exchange string, keep func(entry Entry) bool) error {
+	entries, err := load()
+	if err != nil {
+		return err
+	}
+	var out []Entry
+	for _, entry := range entries {
+		if !strings.EqualFold(entry.Exchange, exchange) || keep(entry) {
+			out = append(out, entry)
+		}
+	}
+	if len(out) == len(entries) {
+		return nil
+	}
+	return save(out)
+}
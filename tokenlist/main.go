@@ -0,0 +1,120 @@
+package tokenlist
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Token is a token on a Uniswap-format token list, please see https://tokenlists.org
+type Token struct {
+	ChainId  int64  `json:"chainId"`
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name"`
+	Decimals int    `json:"decimals"`
+}
+
+type List struct {
+	Name   string  `json:"name"`
+	Tokens []Token `json:"tokens"`
+}
+
+//go:embed default.json
+var defaultList []byte
+
+var (
+	once   sync.Once
+	tokens []Token
+	err    error
+)
+
+// returns the location of your own token lists, for example ~/.config/ladder/tokens
+func dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ladder", "tokens"), nil
+}
+
+func parse(name string, data []byte) ([]Token, error) {
+	var list List
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %v", name, err)
+	}
+	return list.Tokens, nil
+}
+
+// returns the tokens on your own token lists (if any), followed by the tokens on our default token list
+func load() ([]Token, error) {
+	var out []Token
+
+	dir, err := dir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		tokens, err := parse(path, data)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tokens...)
+	}
+
+	tokens, err := parse("default.json", defaultList)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, tokens...), nil
+}
+
+// FindAll returns every token with this symbol (or address) on this chain. a token that is on more than one list
+// is returned once.
+func FindAll(chainId int64, symbol string) ([]Token, error) {
+	once.Do(func() {
+		tokens, err = load()
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out []Token
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		if token.ChainId == chainId && (strings.EqualFold(token.Symbol, symbol) || strings.EqualFold(token.Address, symbol)) {
+			if !seen[strings.ToLower(token.Address)] {
+				seen[strings.ToLower(token.Address)] = true
+				out = append(out, token)
+			}
+		}
+	}
+	return out, nil
+}
+
+// Find returns the token with this symbol (or address) on this chain, or nil if the token isn't on any token list
+func Find(chainId int64, symbol string) (*Token, error) {
+	tokens, err := FindAll(chainId, symbol)
+	if err != nil || len(tokens) == 0 {
+		return nil, err
+	}
+	return &tokens[0], nil
+}